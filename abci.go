@@ -1,7 +1,12 @@
 package poa
 
 import (
+	"bytes"
+	"sort"
+	"strconv"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/ltacker/poa/keeper"
 	"github.com/ltacker/poa/types"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -10,11 +15,155 @@ import (
 // BeginBlocker check for infraction evidence or downtime of validators
 // on every begin block
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
-	return
+	// Distribute the rewards of the previous block
+	// No rewards are distributed for the first block
+	if ctx.BlockHeight() > 1 {
+		k.AllocateRewards(ctx, k.GetPreviousProposer(ctx))
+	}
+
+	// Record the proposer of the current block
+	k.SetPreviousProposer(ctx, sdk.ConsAddress(req.Header.ProposerAddress))
+}
+
+// Open a kick proposal against the validators who missed more consecutive votes than the max missed votes param
+// The kick proposal is authored by the poa module and voted by the validators like any kick proposal
+func proposeInactiveValidatorsKick(ctx sdk.Context, k keeper.Keeper) {
+	maxMissedVotes := k.MaxMissedVotes(ctx)
+	if maxMissedVotes == 0 {
+		return
+	}
+
+	// The validator set can't be empty
+	validators := k.GetAllValidators(ctx)
+	if len(validators) == 1 {
+		return
+	}
+
+	moduleAddr := sdk.ValAddress(supply.NewModuleAddress(types.ModuleName))
+	for _, validator := range validators {
+		participation := k.GetParticipation(ctx, validator.GetOperator())
+		if participation.ConsecutiveMissedVotes <= maxMissedVotes {
+			continue
+		}
+
+		// The validator is already leaving or in a kick proposal
+		if err := proposeKick(ctx, k, validator, moduleAddr, types.KickReasonMissedVotes); err != nil {
+			continue
+		}
+
+		// The missed votes are counted again from the new kick proposal
+		participation.ConsecutiveMissedVotes = 0
+		k.SetParticipation(ctx, validator.GetOperator(), participation)
+	}
+}
+
+// Rank of a validator in the removal of the excess validators, the lowest rank is removed first
+// The validators out of the Tendermint validator set are removed before the joining validators, then the joined validators
+func excessValidatorRank(ctx sdk.Context, k keeper.Keeper, validator types.Validator, state uint16) int {
+	switch {
+	case state == types.ValidatorStateJailing || state == types.ValidatorStateJailed || k.IsValidatorOutOfSet(ctx, validator.GetOperator()):
+		return 0
+	case state == types.ValidatorStateJoining:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Schedule the removal of the validators exceeding the max validators param
+// The param can be lowered below the number of validators by a param change
+// The jailed validators leave first, then the joining validators and the most recently joined validators
+// The last bonded validator is never removed, the Tendermint validator set can't be empty
+func removeExcessValidators(ctx sdk.Context, k keeper.Keeper) {
+	maxValidators := uint64(k.MaxValidators(ctx))
+
+	// The leaving validators are removed at the end of the block
+	validatorCount := k.GetValidatorCount(ctx) - k.GetValidatorStateCount(ctx, types.ValidatorStateLeaving)
+	if validatorCount <= maxValidators {
+		return
+	}
+
+	var validators []types.Validator
+	states := make(map[string]uint16)
+	ranks := make(map[string]int)
+	joinHeights := make(map[string]int64)
+	bondedCount := k.GetBondedValidatorCount(ctx)
+	for _, validator := range k.GetAllValidators(ctx) {
+		state, _ := k.GetValidatorState(ctx, validator.GetOperator())
+		if state == types.ValidatorStateLeaving {
+			continue
+		}
+		operator := validator.GetOperator().String()
+		validators = append(validators, validator)
+		states[operator] = state
+		ranks[operator] = excessValidatorRank(ctx, k, validator, state)
+		joinHeights[operator] = k.GetValidatorJoinHeight(ctx, validator.GetOperator())
+	}
+
+	// Sort the validators by rank, then from the most recently joined, the validators joined at the same height are sorted by operator address
+	sort.Slice(validators, func(i, j int) bool {
+		operatorI := validators[i].GetOperator().String()
+		operatorJ := validators[j].GetOperator().String()
+		if ranks[operatorI] != ranks[operatorJ] {
+			return ranks[operatorI] < ranks[operatorJ]
+		}
+		if joinHeights[operatorI] != joinHeights[operatorJ] {
+			return joinHeights[operatorI] > joinHeights[operatorJ]
+		}
+		return bytes.Compare(validators[i].GetOperator(), validators[j].GetOperator()) > 0
+	})
+
+	for _, validator := range validators[:validatorCount-maxValidators] {
+		operator := validator.GetOperator().String()
+		if ranks[operator] != 0 {
+			// The last bonded validator remains, the next validators are bonded as well
+			if bondedCount == 1 {
+				return
+			}
+			bondedCount--
+		}
+
+		// A joining validator is not yet in the Tendermint validator set
+		if states[operator] == types.ValidatorStateJoining {
+			k.SetValidatorOutOfSet(ctx, validator.GetOperator())
+		}
+		k.SetValidatorState(ctx, validator, types.ValidatorStateLeaving)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeRemoveExcessValidator,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+				sdk.NewAttribute(types.AttributeKeyValidator, validator.GetOperator().String()),
+				sdk.NewAttribute(types.AttributeKeyMaxValidators, strconv.FormatUint(maxValidators, 10)),
+			),
+		)
+	}
+}
+
+// Emit the event of a validator update returned to Tendermint
+func emitValidatorUpdateEvent(ctx sdk.Context, eventType string, validator types.Validator, update abci.ValidatorUpdate) {
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			eventType,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(types.AttributeKeyValidator, validator.GetOperator().String()),
+			sdk.NewAttribute(types.AttributeKeyConsensusPubkey, validator.GetConsPubKeyString()),
+			sdk.NewAttribute(types.AttributeKeyPower, strconv.FormatInt(update.Power, 10)),
+		),
+	)
 }
 
 // EndBlocker called every block, process inflation, update validator set.
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) (updates []abci.ValidatorUpdate) {
+	// Notify the removal of the validators that left the last commit
+	k.DequeueRemovedValidators(ctx)
+
+	// Propose to kick the validators who stopped voting
+	proposeInactiveValidatorsKick(ctx, k)
+
+	// Remove the validators exceeding the max validators param
+	removeExcessValidators(ctx, k)
+
 	// Retrieve all validators
 	validators := k.GetAllValidators(ctx)
 
@@ -34,13 +183,45 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (updates []abci.ValidatorUpdat
 
 		case types.ValidatorStateJoining:
 			// Return the new validator in the updates and set its state to joined
-			updates = append(updates, validator.ABCIValidatorUpdateAppend())
+			update := validator.ABCIValidatorUpdateAppend()
+			updates = append(updates, update)
+			emitValidatorUpdateEvent(ctx, types.EventTypeValidatorJoined, validator, update)
 			k.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+			k.AfterValidatorJoined(ctx, validator.GetConsAddr(), validator.GetOperator())
+
+		case types.ValidatorStateJailing:
+			// Set the validator power to 0 and keep it in the keeper as jailed
+			update := validator.ABCIValidatorUpdateRemove()
+			updates = append(updates, update)
+			emitValidatorUpdateEvent(ctx, types.EventTypeValidatorRemoved, validator, update)
+			k.SetValidatorState(ctx, validator, types.ValidatorStateJailed)
+			k.SetValidatorOutOfSet(ctx, validator.GetOperator())
+
+		case types.ValidatorStateJailed:
+			// No update if the validator is jailed, it is already removed from Tendermint validator set
 
 		case types.ValidatorStateLeaving:
 			// Set the validator power to 0 and remove it from the keeper
-			updates = append(updates, validator.ABCIValidatorUpdateRemove())
+			// A jailed validator is already removed from Tendermint validator set
+			if !k.IsValidatorOutOfSet(ctx, validator.GetOperator()) {
+				update := validator.ABCIValidatorUpdateRemove()
+				updates = append(updates, update)
+				emitValidatorUpdateEvent(ctx, types.EventTypeValidatorRemoved, validator, update)
+			}
+			k.BeforeValidatorRemoved(ctx, validator.GetConsAddr(), validator.GetOperator())
+
+			// Pay out the remaining rewards of the validator
+			if _, err := k.WithdrawRewards(ctx, validator.GetOperator()); err != nil {
+				panic(err)
+			}
+
+			// A kick proposal can no longer be voted once the validator is removed
+			kickProposal, found := k.GetKickProposal(ctx, validator.GetOperator())
+			if found {
+				k.CloseKickProposal(ctx, kickProposal, types.ProposalOutcomeExpired)
+			}
 			k.RemoveValidator(ctx, validator.GetOperator())
+			k.QueueRemovedValidator(ctx, validator)
 
 		default:
 			panic("A validator has a unknown state")
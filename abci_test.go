@@ -1,16 +1,19 @@
 package poa_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/keeper"
 	"github.com/ltacker/poa/types"
 )
 
 func TestEndBlocker(t *testing.T) {
 	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
 	validator1, _ := poa.MockValidator()
 	validator2, _ := poa.MockValidator()
 	validator3, _ := poa.MockValidator()
@@ -82,3 +85,316 @@ func TestEndBlocker(t *testing.T) {
 		t.Errorf("EndBlocker should remove validator 4 and 5 from the set: %v, %v", found4, found5)
 	}
 }
+
+func TestEndBlockerJail(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.AppendValidator(ctx, validator2)
+	poaKeeper.SetValidatorState(ctx, validator1, types.ValidatorStateJoined)
+	poaKeeper.SetValidatorState(ctx, validator2, types.ValidatorStateJoined)
+
+	// A jailing validator is removed from Tendermint validator set
+	poaKeeper.Jail(ctx, validator1.GetConsAddr())
+	updates := poa.EndBlocker(ctx, poaKeeper)
+	if len(updates) != 1 || updates[0].GetPower() != 0 {
+		t.Fatalf("EndBlocker should remove the jailing validator, got %v", updates)
+	}
+	state, found := poaKeeper.GetValidatorState(ctx, validator1.GetOperator())
+	if !found || state != types.ValidatorStateJailed {
+		t.Errorf("EndBlocker should keep the jailing validator with the jailed state, got %v", state)
+	}
+
+	// A jailed validator leaving the validator set has no update
+	poaKeeper.SetValidatorState(ctx, validator1, types.ValidatorStateLeaving)
+	updates = poa.EndBlocker(ctx, poaKeeper)
+	if len(updates) != 0 {
+		t.Errorf("EndBlocker should not update a jailed validator leaving the validator set, got %v", updates)
+	}
+	_, found = poaKeeper.GetValidator(ctx, validator1.GetOperator())
+	if found {
+		t.Errorf("EndBlocker should remove a jailed validator leaving the validator set")
+	}
+
+	// An unjailed validator joins back Tendermint validator set
+	poaKeeper.Jail(ctx, validator2.GetConsAddr())
+	poa.EndBlocker(ctx, poaKeeper)
+	poaKeeper.Unjail(ctx, validator2.GetConsAddr())
+	updates = poa.EndBlocker(ctx, poaKeeper)
+	if len(updates) != 1 || updates[0].GetPower() != 1 {
+		t.Errorf("EndBlocker should append back the unjailed validator, got %v", updates)
+	}
+}
+
+func TestEndBlockerMissedVotesKick(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetParams(ctx, types.DefaultParams().WithMaxMissedVotes(1))
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	validator3, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.AppendValidator(ctx, validator2)
+	poaKeeper.AppendValidator(ctx, validator3)
+
+	// Validator 3 misses the votes on 2 proposals that expire
+	candidate, _ := poa.MockValidator()
+	missVote := func() {
+		poaKeeper.AppendApplication(ctx.WithBlockHeight(1), candidate)
+		application, _ := poaKeeper.GetApplication(ctx, candidate.GetOperator())
+		poaKeeper.CloseApplication(ctx, application, types.ProposalOutcomeExpired)
+	}
+	missVote()
+	poa.EndBlocker(ctx, poaKeeper)
+	_, found := poaKeeper.GetKickProposal(ctx, validator3.GetOperator())
+	if found {
+		t.Errorf("EndBlocker should not propose to kick a validator missing max missed votes")
+	}
+	missVote()
+	poa.EndBlocker(ctx, poaKeeper)
+
+	// The kick proposal is authored by the module with the missed votes reason
+	kickProposal, found := poaKeeper.GetKickProposal(ctx, validator3.GetOperator())
+	if !found {
+		t.Fatalf("EndBlocker should propose to kick a validator missing more than max missed votes")
+	}
+	if kickProposal.GetTotal() != 0 {
+		t.Errorf("The automatic kick proposal should have no vote, got %v", kickProposal.GetTotal())
+	}
+	if poaKeeper.GetKickProposalReason(ctx, validator3.GetOperator()) != types.KickReasonMissedVotes {
+		t.Errorf("The automatic kick proposal should have the missed votes reason")
+	}
+	if poaKeeper.GetParticipation(ctx, validator3.GetOperator()).ConsecutiveMissedVotes != 0 {
+		t.Errorf("The consecutive missed votes should be reset when the kick proposal is opened")
+	}
+
+	// The kick proposal is voted like any kick proposal
+	handler := poa.NewHandler(poaKeeper)
+	handler(ctx, types.NewMsgVote(types.VoteTypeKickProposal, validator1.GetOperator(), validator3.GetOperator(), true))
+	_, err := handler(ctx, types.NewMsgVote(types.VoteTypeKickProposal, validator2.GetOperator(), validator3.GetOperator(), true))
+	if err != nil {
+		t.Fatalf("The automatic kick proposal should be voted, got error %v", err)
+	}
+	state, _ := poaKeeper.GetValidatorState(ctx, validator3.GetOperator())
+	if state != types.ValidatorStateLeaving {
+		t.Errorf("An approved automatic kick proposal should kick the validator, got state %v", state)
+	}
+	if poaKeeper.GetKickProposalReason(ctx, validator3.GetOperator()) != types.KickReasonProposed {
+		t.Errorf("The reason should be removed with the kick proposal")
+	}
+}
+
+func TestEndBlockerEvents(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	joining, _ := poa.MockValidator()
+	leaving, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, joining)
+	poaKeeper.AppendValidator(ctx, leaving)
+	poaKeeper.SetValidatorState(ctx, leaving, types.ValidatorStateLeaving)
+
+	poa.EndBlocker(ctx, poaKeeper)
+
+	// Each update returned to Tendermint has an event
+	expected := map[string]struct {
+		validator string
+		pubkey    string
+		power     string
+	}{
+		types.EventTypeValidatorJoined:  {joining.GetOperator().String(), joining.GetConsPubKeyString(), "1"},
+		types.EventTypeValidatorRemoved: {leaving.GetOperator().String(), leaving.GetConsPubKeyString(), "0"},
+	}
+	found := 0
+	for _, event := range ctx.EventManager().Events() {
+		want, ok := expected[event.Type]
+		if !ok {
+			continue
+		}
+		found++
+
+		attributes := make(map[string]string)
+		for _, attribute := range event.Attributes {
+			attributes[string(attribute.Key)] = string(attribute.Value)
+		}
+		if attributes[types.AttributeKeyValidator] != want.validator {
+			t.Errorf("The %v event should have the validator %v, got %v", event.Type, want.validator, attributes[types.AttributeKeyValidator])
+		}
+		if attributes[types.AttributeKeyConsensusPubkey] != want.pubkey {
+			t.Errorf("The %v event should have the consensus pubkey %v, got %v", event.Type, want.pubkey, attributes[types.AttributeKeyConsensusPubkey])
+		}
+		if attributes[types.AttributeKeyPower] != want.power {
+			t.Errorf("The %v event should have the power %v, got %v", event.Type, want.power, attributes[types.AttributeKeyPower])
+		}
+	}
+	if found != 2 {
+		t.Errorf("EndBlocker should emit 2 validator set change events, got %v", found)
+	}
+}
+
+func TestEndBlockerExcessValidators(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	validator3, _ := poa.MockValidator()
+	validator4, _ := poa.MockValidator()
+	validator5, _ := poa.MockValidator()
+	leaving, _ := poa.MockValidator()
+
+	// The validators join at different heights, validator 5 is still joining
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.SetValidatorState(ctx, validator1, types.ValidatorStateJoined)
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(5), validator2)
+	poaKeeper.SetValidatorState(ctx, validator2, types.ValidatorStateJoined)
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(10), validator3)
+	poaKeeper.SetValidatorState(ctx, validator3, types.ValidatorStateJoined)
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(15), validator4)
+	poaKeeper.SetValidatorState(ctx, validator4, types.ValidatorStateJoined)
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(20), validator5)
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(25), leaving)
+	poaKeeper.SetValidatorState(ctx, leaving, types.ValidatorStateLeaving)
+	poaKeeper.AppendKickProposal(ctx, validator4)
+
+	// The max validators is lowered below the number of validators
+	// The leaving validator is not counted, the 2 most recently joined validators leave
+	poaKeeper.SetParams(ctx, types.NewParams(3, types.DefaultQuorum))
+	updates := poa.EndBlocker(ctx, poaKeeper)
+
+	if poaKeeper.GetValidatorCount(ctx) != 3 {
+		t.Errorf("EndBlocker should keep 3 validators, got %v", poaKeeper.GetValidatorCount(ctx))
+	}
+	for _, validator := range []types.Validator{validator1, validator2, validator3} {
+		if _, found := poaKeeper.GetValidator(ctx, validator.GetOperator()); !found {
+			t.Errorf("EndBlocker should keep the validator %v", validator.GetOperator())
+		}
+	}
+	for _, validator := range []types.Validator{validator4, validator5, leaving} {
+		if _, found := poaKeeper.GetValidator(ctx, validator.GetOperator()); found {
+			t.Errorf("EndBlocker should remove the validator %v", validator.GetOperator())
+		}
+	}
+	if _, found := poaKeeper.GetKickProposal(ctx, validator4.GetOperator()); found {
+		t.Errorf("EndBlocker should close the kick proposal against a removed validator")
+	}
+
+	// The joining validator has never been in the Tendermint validator set
+	if len(updates) != 2 {
+		t.Errorf("EndBlocker should perform 2 updates, found %v updates", len(updates))
+	}
+	joiningUpdate := validator5.ABCIValidatorUpdateRemove()
+	for _, update := range updates {
+		if cmp.Equal(update.GetPubKey(), joiningUpdate.GetPubKey()) {
+			t.Errorf("EndBlocker should not remove the joining validator from the Tendermint validator set")
+		}
+	}
+
+	// Each removal has an event
+	removed := make(map[string]bool)
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != types.EventTypeRemoveExcessValidator {
+			continue
+		}
+		attributes := make(map[string]string)
+		for _, attribute := range event.Attributes {
+			attributes[string(attribute.Key)] = string(attribute.Value)
+		}
+		if attributes[types.AttributeKeyMaxValidators] != "3" {
+			t.Errorf("The %v event should have the max validators 3, got %v", event.Type, attributes[types.AttributeKeyMaxValidators])
+		}
+		removed[attributes[types.AttributeKeyValidator]] = true
+	}
+	if len(removed) != 2 || !removed[validator4.GetOperator().String()] || !removed[validator5.GetOperator().String()] {
+		t.Errorf("EndBlocker should emit an event for validator 4 and 5, got %v", removed)
+	}
+
+	// No validator is removed once the validator set fits the max validators
+	poa.EndBlocker(ctx, poaKeeper)
+	if poaKeeper.GetValidatorCount(ctx) != 3 {
+		t.Errorf("EndBlocker should not remove a validator below the max validators, got %v validators", poaKeeper.GetValidatorCount(ctx))
+	}
+	if msg, broken := keeper.AllInvariants(poaKeeper)(ctx); broken {
+		t.Errorf("EndBlocker should leave a consistent store: %v", msg)
+	}
+}
+
+func TestEndBlockerExcessGenesisValidators(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetParams(ctx, types.NewParams(1, types.DefaultQuorum))
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+
+	// The genesis validators joined at the same height, the validator with the greatest operator address leaves
+	for _, validator := range []types.Validator{validator1, validator2} {
+		poaKeeper.SetValidator(ctx, validator)
+		poaKeeper.SetValidatorByConsAddr(ctx, validator)
+		poaKeeper.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+	}
+	kept, removed := validator1, validator2
+	if bytes.Compare(validator1.GetOperator(), validator2.GetOperator()) > 0 {
+		kept, removed = validator2, validator1
+	}
+
+	poa.EndBlocker(ctx, poaKeeper)
+	if _, found := poaKeeper.GetValidator(ctx, kept.GetOperator()); !found {
+		t.Errorf("EndBlocker should keep the validator with the lowest operator address")
+	}
+	if _, found := poaKeeper.GetValidator(ctx, removed.GetOperator()); found {
+		t.Errorf("EndBlocker should remove the validator with the greatest operator address")
+	}
+}
+
+func TestEndBlockerExcessJailedValidator(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetParams(ctx, types.NewParams(1, types.DefaultQuorum))
+	jailed, _ := poa.MockValidator()
+	joined, _ := poa.MockValidator()
+
+	// The jailed validator joined before the joined validator
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(5), jailed)
+	poaKeeper.SetValidatorState(ctx, jailed, types.ValidatorStateJailed)
+	poaKeeper.SetValidatorOutOfSet(ctx, jailed.GetOperator())
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(10), joined)
+	poaKeeper.SetValidatorState(ctx, joined, types.ValidatorStateJoined)
+
+	// The jailed validator leaves first, it is already out of the Tendermint validator set
+	updates := poa.EndBlocker(ctx, poaKeeper)
+	if _, found := poaKeeper.GetValidator(ctx, jailed.GetOperator()); found {
+		t.Errorf("EndBlocker should remove the jailed validator")
+	}
+	if _, found := poaKeeper.GetValidator(ctx, joined.GetOperator()); !found {
+		t.Errorf("EndBlocker should keep the joined validator")
+	}
+	if len(updates) != 0 {
+		t.Errorf("EndBlocker should not update the Tendermint validator set, got %v", updates)
+	}
+}
+
+func TestEndBlockerExcessLastBondedValidator(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetParams(ctx, types.NewParams(1, types.DefaultQuorum))
+	jailed1, _ := poa.MockValidator()
+	jailed2, _ := poa.MockValidator()
+	joined, _ := poa.MockValidator()
+
+	// The jailed validators joined after the joined validator
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(5), joined)
+	poaKeeper.SetValidatorState(ctx, joined, types.ValidatorStateJoined)
+	for _, validator := range []types.Validator{jailed1, jailed2} {
+		poaKeeper.AppendValidator(ctx.WithBlockHeight(10), validator)
+		poaKeeper.SetValidatorState(ctx, validator, types.ValidatorStateJailed)
+		poaKeeper.SetValidatorOutOfSet(ctx, validator.GetOperator())
+	}
+
+	// The jailed validators leave, the last bonded validator is kept
+	poa.EndBlocker(ctx, poaKeeper)
+	if poaKeeper.GetValidatorCount(ctx) != 1 {
+		t.Errorf("EndBlocker should keep 1 validator, got %v", poaKeeper.GetValidatorCount(ctx))
+	}
+	if _, found := poaKeeper.GetValidator(ctx, joined.GetOperator()); !found {
+		t.Errorf("EndBlocker should never remove the last bonded validator")
+	}
+}
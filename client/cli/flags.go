@@ -12,6 +12,14 @@ const (
 	FlagWebsite         = "website"
 	FlagSecurityContact = "security-contact"
 	FlagDetails         = "details"
+
+	FlagPage   = "page"
+	FlagLimit  = "limit"
+	FlagStatus = "status"
+	FlagSortBy = "sort-by"
+
+	FlagCandidate = "candidate"
+	FlagOutcome   = "outcome"
 )
 
 // common flagsets to add to various functions
@@ -34,3 +42,13 @@ func FlagSetDescriptionCreate() *flag.FlagSet {
 
 	return fs
 }
+
+func FlagSetList() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+
+	fs.Int(FlagPage, 1, "The page of the results")
+	fs.Int(FlagLimit, 0, "The number of results per page, all the results are returned if 0")
+	fs.String(FlagSortBy, "", "The order of the results (operator|moniker|approvals)")
+
+	return fs
+}
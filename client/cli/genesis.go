@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/privval"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+
+	"github.com/ltacker/poa/types"
+)
+
+const (
+	FlagClientHome = "home-client"
+	FlagPubKey     = "pubkey"
+)
+
+// AddGenesisValidatorCmd returns the command appending a validator to the poa genesis state of genesis.json
+func AddGenesisValidatorCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome, defaultClientHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-genesis-validator [operator-addr|key-name]",
+		Short: "Add a validator to the poa genesis state of genesis.json",
+		Long: `Add a validator to the poa genesis state of genesis.json.
+The consensus public key is read from the priv_validator_key.json file of the node home directory if --pubkey is not provided.
+The operator address can be provided as a validator address, an account address or the name of a key of the keyring.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			home, _ := cmd.Flags().GetString(cli.HomeFlag)
+			config.SetRoot(home)
+
+			operator, err := operatorAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			// Consensus public key of the validator
+			var pk crypto.PubKey
+			if pubkey, _ := cmd.Flags().GetString(FlagPubKey); pubkey != "" {
+				pk, err = sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeConsPub, pubkey)
+				if err != nil {
+					return fmt.Errorf("cannot convert pubkey: %v", err)
+				}
+			} else {
+				pk, err = readNodeConsensusPubKey(cdc, config.PrivValidatorKeyFile())
+				if err != nil {
+					return err
+				}
+			}
+
+			// Description of the validator
+			moniker, _ := cmd.Flags().GetString(FlagMoniker)
+			identity, _ := cmd.Flags().GetString(FlagIdentity)
+			website, _ := cmd.Flags().GetString(FlagWebsite)
+			security, _ := cmd.Flags().GetString(FlagSecurityContact)
+			details, _ := cmd.Flags().GetString(FlagDetails)
+			description := types.NewDescription(moniker, identity, website, security, details)
+
+			validator := types.NewValidator(operator, pk, description)
+			if err := validator.CheckValid(); err != nil {
+				return err
+			}
+
+			genFile := config.GenesisFile()
+			appState, genDoc, genState, err := readPoaGenesis(cdc, genFile)
+			if err != nil {
+				return err
+			}
+
+			genState.Validators = append(genState.Validators, validator)
+
+			return writePoaGenesis(cdc, genFile, genDoc, appState, genState)
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().String(FlagClientHome, defaultClientHome, "client's home directory")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	cmd.Flags().String(FlagPubKey, "", "The Bech32 consensus public key of the validator, read from the node home directory if empty")
+	cmd.Flags().AddFlagSet(FlagSetDescriptionCreate())
+
+	return cmd
+}
+
+// RemoveGenesisValidatorCmd returns the command removing a validator from the poa genesis state of genesis.json
+func RemoveGenesisValidatorCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome, defaultClientHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-genesis-validator [operator-addr|key-name]",
+		Short: "Remove a validator from the poa genesis state of genesis.json",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			home, _ := cmd.Flags().GetString(cli.HomeFlag)
+			config.SetRoot(home)
+
+			operator, err := operatorAddress(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			genFile := config.GenesisFile()
+			appState, genDoc, genState, err := readPoaGenesis(cdc, genFile)
+			if err != nil {
+				return err
+			}
+
+			// Remove the validator from the genesis validators
+			validators := []types.Validator{}
+			for _, validator := range genState.Validators {
+				if !validator.GetOperator().Equals(operator) {
+					validators = append(validators, validator)
+				}
+			}
+			if len(validators) == len(genState.Validators) {
+				return fmt.Errorf("the validator %s is not in the genesis state", operator)
+			}
+			genState.Validators = validators
+
+			// Remove the state, the rewards and the withdraw address of the validator
+			validatorStates := []types.GenesisValidatorState{}
+			for _, validatorState := range genState.ValidatorStates {
+				if !validatorState.OperatorAddress.Equals(operator) {
+					validatorStates = append(validatorStates, validatorState)
+				}
+			}
+			genState.ValidatorStates = validatorStates
+
+			rewards := []types.GenesisValidatorRewards{}
+			for _, validatorRewards := range genState.Rewards {
+				if !validatorRewards.OperatorAddress.Equals(operator) {
+					rewards = append(rewards, validatorRewards)
+					continue
+				}
+
+				// The balance of the poa module account must match the sum of the rewards
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: the rewards %s of the validator %s are dropped, "+
+					"the balance of the %s module account must be lowered by the same amount\n", validatorRewards.Rewards, operator, types.ModuleName)
+			}
+			genState.Rewards = rewards
+
+			withdrawAddresses := []types.GenesisWithdrawAddress{}
+			for _, withdrawAddress := range genState.WithdrawAddresses {
+				if !withdrawAddress.OperatorAddress.Equals(operator) {
+					withdrawAddresses = append(withdrawAddresses, withdrawAddress)
+				}
+			}
+			genState.WithdrawAddresses = withdrawAddresses
+
+			return writePoaGenesis(cdc, genFile, genDoc, appState, genState)
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().String(FlagClientHome, defaultClientHome, "client's home directory")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+
+	return cmd
+}
+
+// Get the operator address from a validator address, an account address or the name of a key of the keyring
+func operatorAddress(cmd *cobra.Command, addrOrKeyName string) (sdk.ValAddress, error) {
+	if addr, err := sdk.ValAddressFromBech32(addrOrKeyName); err == nil {
+		return addr, nil
+	}
+	if addr, err := sdk.AccAddressFromBech32(addrOrKeyName); err == nil {
+		return sdk.ValAddress(addr), nil
+	}
+
+	// The argument is the name of a key
+	clientHome, _ := cmd.Flags().GetString(FlagClientHome)
+	keyringBackend, _ := cmd.Flags().GetString(flags.FlagKeyringBackend)
+	kb, err := keys.NewKeyring(sdk.KeyringServiceName(), keyringBackend, clientHome, bufio.NewReader(cmd.InOrStdin()))
+	if err != nil {
+		return nil, err
+	}
+	info, err := kb.Get(addrOrKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdk.ValAddress(info.GetAddress()), nil
+}
+
+// Read the consensus public key from the private validator key file of a node
+func readNodeConsensusPubKey(cdc *codec.Codec, keyFile string) (crypto.PubKey, error) {
+	bz, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the validator key of the node, provide --%s or --%s: %v", FlagPubKey, cli.HomeFlag, err)
+	}
+
+	var pvKey privval.FilePVKey
+	if err := cdc.UnmarshalJSON(bz, &pvKey); err != nil {
+		return nil, fmt.Errorf("cannot read the validator key from %s: %v", keyFile, err)
+	}
+
+	return pvKey.PrivKey.PubKey(), nil
+}
+
+// Read the application state, the genesis document and the poa genesis state from a genesis file
+func readPoaGenesis(cdc *codec.Codec, genFile string) (map[string]json.RawMessage, *tmtypes.GenesisDoc, types.GenesisState, error) {
+	var genState types.GenesisState
+
+	appState, genDoc, err := genutil.GenesisStateFromGenFile(cdc, genFile)
+	if err != nil {
+		return nil, nil, genState, fmt.Errorf("failed to unmarshal genesis state: %v", err)
+	}
+
+	if err := cdc.UnmarshalJSON(appState[types.ModuleName], &genState); err != nil {
+		return nil, nil, genState, fmt.Errorf("failed to unmarshal poa genesis state: %v", err)
+	}
+
+	return appState, genDoc, genState, nil
+}
+
+// Validate the poa genesis state and write it in the genesis file
+func writePoaGenesis(cdc *codec.Codec, genFile string, genDoc *tmtypes.GenesisDoc, appState map[string]json.RawMessage, genState types.GenesisState) error {
+	if err := types.ValidateGenesis(genState); err != nil {
+		return fmt.Errorf("invalid poa genesis state: %v", err)
+	}
+
+	genStateBz, err := cdc.MarshalJSON(genState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal poa genesis state: %v", err)
+	}
+	appState[types.ModuleName] = genStateBz
+
+	appStateJSON, err := cdc.MarshalJSON(appState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal application genesis state: %v", err)
+	}
+	genDoc.AppState = appStateJSON
+
+	return genutil.ExportGenesisFile(genDoc, genFile)
+}
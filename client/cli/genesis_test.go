@@ -0,0 +1,203 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/privval"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+
+	"github.com/ltacker/poa"
+	poacli "github.com/ltacker/poa/client/cli"
+	"github.com/ltacker/poa/types"
+)
+
+// Create a node home directory with a genesis file containing the default poa genesis state
+func setupGenesisHome(t *testing.T, cdc *codec.Codec) string {
+	home, err := ioutil.TempDir("", "poa-genesis")
+	if err != nil {
+		t.Fatalf("Cannot create the home directory: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(home, "config"), 0700); err != nil {
+		t.Fatalf("Cannot create the config directory: %v", err)
+	}
+
+	appState, err := cdc.MarshalJSON(map[string]json.RawMessage{
+		types.ModuleName: cdc.MustMarshalJSON(types.DefaultGenesisState()),
+	})
+	if err != nil {
+		t.Fatalf("Cannot marshal the application state: %v", err)
+	}
+	genDoc := &tmtypes.GenesisDoc{ChainID: "poa-chain", AppState: appState}
+	if err := genutil.ExportGenesisFile(genDoc, filepath.Join(home, "config", "genesis.json")); err != nil {
+		t.Fatalf("Cannot write the genesis file: %v", err)
+	}
+
+	return home
+}
+
+// Get the validators of the poa genesis state of a node home directory
+func genesisValidators(t *testing.T, cdc *codec.Codec, home string) []types.Validator {
+	appState, _, err := genutil.GenesisStateFromGenFile(cdc, filepath.Join(home, "config", "genesis.json"))
+	if err != nil {
+		t.Fatalf("Cannot read the genesis file: %v", err)
+	}
+
+	var genState types.GenesisState
+	cdc.MustUnmarshalJSON(appState[types.ModuleName], &genState)
+	return genState.Validators
+}
+
+// Run a genesis command with the provided arguments
+func runGenesisCmd(cdc *codec.Codec, newCmd func(*server.Context, *codec.Codec, string, string) *cobra.Command, home string, args ...string) error {
+	cmd := newCmd(server.NewDefaultContext(), cdc, home, home)
+	cmd.SetArgs(append(args, "--"+cli.HomeFlag, home))
+	cmd.SetOut(ioutil.Discard)
+	cmd.SetErr(ioutil.Discard)
+	return cmd.Execute()
+}
+
+func TestGenesisValidatorCmds(t *testing.T) {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	home := setupGenesisHome(t, cdc)
+	defer os.RemoveAll(home)
+
+	validator1, _ := poa.MockValidator()
+	operator2 := poa.MockValAddress()
+
+	// Add a validator with its consensus public key
+	err := runGenesisCmd(cdc, poacli.AddGenesisValidatorCmd, home,
+		validator1.GetOperator().String(),
+		"--"+poacli.FlagPubKey, validator1.GetConsPubKeyString(),
+		"--"+poacli.FlagMoniker, "validator1",
+	)
+	if err != nil {
+		t.Fatalf("add-genesis-validator should add a validator, got error %v", err)
+	}
+
+	// Add a validator with the consensus public key of the node
+	filePV := privval.GenFilePV(filepath.Join(home, "config", "priv_validator_key.json"), filepath.Join(home, "data", "priv_validator_state.json"))
+	filePV.Key.Save()
+	err = runGenesisCmd(cdc, poacli.AddGenesisValidatorCmd, home,
+		sdk.AccAddress(operator2).String(),
+		"--"+poacli.FlagMoniker, "validator2",
+		"--"+poacli.FlagWebsite, "https://validator2.example",
+	)
+	if err != nil {
+		t.Fatalf("add-genesis-validator should add a validator with the key of the node, got error %v", err)
+	}
+
+	validators := genesisValidators(t, cdc, home)
+	if len(validators) != 2 {
+		t.Fatalf("The genesis state should contain 2 validators, got %v", len(validators))
+	}
+	if !validators[1].GetOperator().Equals(operator2) || !validators[1].GetConsPubKey().Equals(filePV.Key.PubKey) {
+		t.Errorf("The second validator should have the key of the node, got %v", validators[1])
+	}
+	if validators[1].GetDescription().Website != "https://validator2.example" {
+		t.Errorf("The second validator should have the website of the flag, got %v", validators[1].GetDescription())
+	}
+
+	// A validator cannot be added twice or without description
+	err = runGenesisCmd(cdc, poacli.AddGenesisValidatorCmd, home,
+		validator1.GetOperator().String(),
+		"--"+poacli.FlagPubKey, validator1.GetConsPubKeyString(),
+		"--"+poacli.FlagMoniker, "validator1",
+	)
+	if err == nil {
+		t.Errorf("add-genesis-validator should not add a validator twice")
+	}
+	validator3, _ := poa.MockValidator()
+	err = runGenesisCmd(cdc, poacli.AddGenesisValidatorCmd, home,
+		validator3.GetOperator().String(),
+		"--"+poacli.FlagPubKey, validator3.GetConsPubKeyString(),
+	)
+	if err == nil {
+		t.Errorf("add-genesis-validator should not add a validator without description")
+	}
+
+	// Remove a validator
+	err = runGenesisCmd(cdc, poacli.RemoveGenesisValidatorCmd, home, validator1.GetOperator().String())
+	if err != nil {
+		t.Fatalf("remove-genesis-validator should remove a validator, got error %v", err)
+	}
+	validators = genesisValidators(t, cdc, home)
+	if len(validators) != 1 || !validators[0].GetOperator().Equals(operator2) {
+		t.Errorf("The genesis state should only contain the second validator, got %v", validators)
+	}
+	err = runGenesisCmd(cdc, poacli.RemoveGenesisValidatorCmd, home, validator1.GetOperator().String())
+	if err == nil {
+		t.Errorf("remove-genesis-validator should fail if the validator is not in the genesis state")
+	}
+}
+
+func TestRemoveGenesisValidatorEntries(t *testing.T) {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	home := setupGenesisHome(t, cdc)
+	defer os.RemoveAll(home)
+
+	// A genesis state with the state, the rewards and the withdraw address of the removed validator
+	removed, _ := poa.MockValidator()
+	kept, _ := poa.MockValidator()
+	rewards := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	genState := types.NewGenesisState(types.DefaultParams(), []types.Validator{removed, kept})
+	genState.ValidatorStates = []types.GenesisValidatorState{types.NewGenesisValidatorState(removed.GetOperator(), types.ValidatorStateJailed)}
+	genState.Rewards = []types.GenesisValidatorRewards{
+		types.NewGenesisValidatorRewards(removed.GetOperator(), rewards),
+		types.NewGenesisValidatorRewards(kept.GetOperator(), rewards),
+	}
+	genState.WithdrawAddresses = []types.GenesisWithdrawAddress{types.NewGenesisWithdrawAddress(removed.GetOperator(), sdk.AccAddress(kept.GetOperator()))}
+	genFile := filepath.Join(home, "config", "genesis.json")
+	appState, genDoc, err := genutil.GenesisStateFromGenFile(cdc, genFile)
+	if err != nil {
+		t.Fatalf("Cannot read the genesis file: %v", err)
+	}
+	appState[types.ModuleName] = cdc.MustMarshalJSON(genState)
+	genDoc.AppState = cdc.MustMarshalJSON(appState)
+	if err := genutil.ExportGenesisFile(genDoc, genFile); err != nil {
+		t.Fatalf("Cannot write the genesis file: %v", err)
+	}
+
+	// The entries of the validator are removed with the validator, dropping the rewards is warned
+	var stderr bytes.Buffer
+	cmd := poacli.RemoveGenesisValidatorCmd(server.NewDefaultContext(), cdc, home, home)
+	cmd.SetArgs([]string{removed.GetOperator().String(), "--" + cli.HomeFlag, home})
+	cmd.SetOut(ioutil.Discard)
+	cmd.SetErr(&stderr)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("remove-genesis-validator should remove a validator with entries, got error %v", err)
+	}
+	if !strings.Contains(stderr.String(), "warning") || !strings.Contains(stderr.String(), rewards.String()) {
+		t.Errorf("remove-genesis-validator should warn about the dropped rewards, got %q", stderr.String())
+	}
+
+	appState, _, err = genutil.GenesisStateFromGenFile(cdc, genFile)
+	if err != nil {
+		t.Fatalf("Cannot read the genesis file: %v", err)
+	}
+	var exported types.GenesisState
+	cdc.MustUnmarshalJSON(appState[types.ModuleName], &exported)
+	if err := types.ValidateGenesis(exported); err != nil {
+		t.Errorf("The genesis state should be valid, got error %v", err)
+	}
+	if len(exported.ValidatorStates) != 0 || len(exported.WithdrawAddresses) != 0 {
+		t.Errorf("The state and the withdraw address of the validator should be removed, got %v and %v", exported.ValidatorStates, exported.WithdrawAddresses)
+	}
+	if len(exported.Rewards) != 1 || !exported.Rewards[0].OperatorAddress.Equals(kept.GetOperator()) {
+		t.Errorf("Only the rewards of the kept validator should remain, got %v", exported.Rewards)
+	}
+}
@@ -0,0 +1,292 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/ltacker/poa/types"
+)
+
+const (
+	FlagOutputDocument = "output-document"
+	FlagGenTxDir       = "gentx-dir"
+)
+
+// GetGenesisCmd returns the genesis commands of the poa module for the daemon of the application
+func GetGenesisCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome, defaultClientHome string) *cobra.Command {
+	poaGenesisCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("Genesis subcommands for the %s module", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	poaGenesisCmd.AddCommand(
+		GenTxCmd(ctx, cdc, defaultNodeHome, defaultClientHome),
+		CollectGenTxsCmd(ctx, cdc, defaultNodeHome),
+	)
+
+	return poaGenesisCmd
+}
+
+// GenTxCmd returns the command generating a genesis transaction of a validator signed with its operator key
+func GenTxCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome, defaultClientHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gentx",
+		Short: "Generate a genesis transaction to become a genesis validator",
+		Long: strings.TrimSpace(`Generate a genesis transaction containing the application of a genesis validator.
+The transaction is signed with the operator key provided with --name, the operator address is the address of this key.
+The consensus public key is read from the priv_validator_key.json file of the node home directory if --pubkey is not provided.
+The genesis transactions are merged in the genesis file with collect-gentxs.
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			home, _ := cmd.Flags().GetString(cli.HomeFlag)
+			config.SetRoot(home)
+
+			// The chain ID is part of the signature
+			genFile := config.GenesisFile()
+			_, genDoc, genState, err := readPoaGenesis(cdc, genFile)
+			if err != nil {
+				return err
+			}
+
+			// Get the operator key
+			name, _ := cmd.Flags().GetString(flags.FlagName)
+			clientHome, _ := cmd.Flags().GetString(FlagClientHome)
+			keyringBackend, _ := cmd.Flags().GetString(flags.FlagKeyringBackend)
+			kb, err := keys.NewKeyring(sdk.KeyringServiceName(), keyringBackend, clientHome, bufio.NewReader(cmd.InOrStdin()))
+			if err != nil {
+				return err
+			}
+			info, err := kb.Get(name)
+			if err != nil {
+				return err
+			}
+
+			// Consensus public key of the validator
+			var pk crypto.PubKey
+			if pubkey, _ := cmd.Flags().GetString(FlagPubKey); pubkey != "" {
+				pk, err = sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeConsPub, pubkey)
+				if err != nil {
+					return fmt.Errorf("cannot convert pubkey: %v", err)
+				}
+			} else {
+				pk, err = readNodeConsensusPubKey(cdc, config.PrivValidatorKeyFile())
+				if err != nil {
+					return err
+				}
+			}
+
+			// Description of the validator
+			moniker, _ := cmd.Flags().GetString(FlagMoniker)
+			identity, _ := cmd.Flags().GetString(FlagIdentity)
+			website, _ := cmd.Flags().GetString(FlagWebsite)
+			security, _ := cmd.Flags().GetString(FlagSecurityContact)
+			details, _ := cmd.Flags().GetString(FlagDetails)
+			description := types.NewDescription(moniker, identity, website, security, details)
+
+			validator := types.NewValidator(sdk.ValAddress(info.GetAddress()), pk, description)
+			msg := types.NewMsgSubmitApplication(validator)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			// The validator must be valid in the current genesis state
+			genState.Validators = append(genState.Validators, validator)
+			if err := types.ValidateGenesis(genState); err != nil {
+				return fmt.Errorf("invalid genesis validator: %v", err)
+			}
+
+			// Sign the transaction offline
+			stdTx, err := SignGenTx(kb, name, genDoc.ChainID, msg)
+			if err != nil {
+				return err
+			}
+
+			outputDocument, _ := cmd.Flags().GetString(FlagOutputDocument)
+			if outputDocument == "" {
+				outputDocument = filepath.Join(config.RootDir, "config", "gentx", fmt.Sprintf("gentx-%s.json", validator.GetOperator()))
+			}
+			if err := writeGenTx(cdc, outputDocument, stdTx); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Genesis transaction written to %q\n", outputDocument)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().String(FlagClientHome, defaultClientHome, "client's home directory")
+	cmd.Flags().String(flags.FlagName, "", "name of the operator key to sign the genesis transaction with")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	cmd.Flags().String(FlagPubKey, "", "The Bech32 consensus public key of the validator, read from the node home directory if empty")
+	cmd.Flags().String(FlagOutputDocument, "", "write the genesis transaction to the given file instead of the default location")
+	cmd.Flags().AddFlagSet(FlagSetDescriptionCreate())
+	cmd.MarkFlagRequired(flags.FlagName)
+
+	return cmd
+}
+
+// CollectGenTxsCmd returns the command verifying the genesis transactions and merging their validators in the genesis file
+func CollectGenTxsCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "collect-gentxs",
+		Short: "Verify the genesis transactions and append their validators to the poa genesis state of genesis.json",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			home, _ := cmd.Flags().GetString(cli.HomeFlag)
+			config.SetRoot(home)
+
+			genFile := config.GenesisFile()
+			appState, genDoc, genState, err := readPoaGenesis(cdc, genFile)
+			if err != nil {
+				return err
+			}
+
+			genTxsDir, _ := cmd.Flags().GetString(FlagGenTxDir)
+			if genTxsDir == "" {
+				genTxsDir = filepath.Join(config.RootDir, "config", "gentx")
+			}
+			validators, err := CollectGenTxs(cdc, genDoc.ChainID, genTxsDir)
+			if err != nil {
+				return err
+			}
+
+			genState.Validators = append(genState.Validators, validators...)
+			if err := writePoaGenesis(cdc, genFile, genDoc, appState, genState); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "%d genesis validators collected from %q\n", len(validators), genTxsDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().String(FlagGenTxDir, "", "directory of the genesis transactions, [--home]/config/gentx/ by default")
+
+	return cmd
+}
+
+// CollectGenTxs reads and verifies the genesis transactions of a directory, and returns their validators
+func CollectGenTxs(cdc *codec.Codec, chainID string, genTxsDir string) ([]types.Validator, error) {
+	files, err := ioutil.ReadDir(genTxsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var validators []types.Validator
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		bz, err := ioutil.ReadFile(filepath.Join(genTxsDir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var stdTx auth.StdTx
+		if err := cdc.UnmarshalJSON(bz, &stdTx); err != nil {
+			return nil, fmt.Errorf("failed to read the genesis transaction %s: %v", file.Name(), err)
+		}
+
+		validator, err := ValidateGenTx(chainID, stdTx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis transaction %s: %v", file.Name(), err)
+		}
+		validators = append(validators, validator)
+	}
+
+	return validators, nil
+}
+
+// ValidateGenTx verifies that a genesis transaction contains a valid application signed by the operator of the validator
+func ValidateGenTx(chainID string, stdTx auth.StdTx) (types.Validator, error) {
+	msgs := stdTx.GetMsgs()
+	if len(msgs) != 1 {
+		return types.Validator{}, fmt.Errorf("the genesis transaction must contain one message, got %d", len(msgs))
+	}
+	msg, ok := msgs[0].(types.MsgSubmitApplication)
+	if !ok {
+		return types.Validator{}, fmt.Errorf("the message of the genesis transaction must be a %s", types.SubmitApplicationConst)
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return types.Validator{}, err
+	}
+
+	// The consensus public key must be valid
+	if _, err := sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeConsPub, msg.Candidate.GetConsPubKeyString()); err != nil {
+		return types.Validator{}, fmt.Errorf("invalid consensus pubkey: %v", err)
+	}
+
+	// The message must be signed by the operator
+	sigs := stdTx.Signatures
+	if len(sigs) != 1 {
+		return types.Validator{}, fmt.Errorf("the genesis transaction must contain one signature, got %d", len(sigs))
+	}
+	if sigs[0].PubKey == nil {
+		return types.Validator{}, fmt.Errorf("the signature of the genesis transaction has no public key")
+	}
+	if !sdk.AccAddress(sigs[0].PubKey.Address()).Equals(msg.GetSigners()[0]) {
+		return types.Validator{}, fmt.Errorf("the genesis transaction is not signed by the operator %s", msg.Candidate.GetOperator())
+	}
+	signBytes := auth.StdSignBytes(chainID, 0, 0, stdTx.Fee, msgs, stdTx.GetMemo())
+	if !sigs[0].PubKey.VerifyBytes(signBytes, sigs[0].Signature) {
+		return types.Validator{}, fmt.Errorf("invalid signature of the genesis transaction for the chain %s", chainID)
+	}
+
+	return msg.Candidate, nil
+}
+
+// SignGenTx signs a genesis transaction with the account number and the sequence at 0
+func SignGenTx(kb keys.Keybase, name string, chainID string, msg types.MsgSubmitApplication) (auth.StdTx, error) {
+	fee := auth.NewStdFee(0, sdk.NewCoins())
+	signMsg := auth.StdSignMsg{
+		ChainID: chainID,
+		Fee:     fee,
+		Msgs:    []sdk.Msg{msg},
+	}
+
+	sig, err := auth.MakeSignature(kb, name, "", signMsg)
+	if err != nil {
+		return auth.StdTx{}, err
+	}
+
+	return auth.NewStdTx(signMsg.Msgs, fee, []auth.StdSignature{sig}, ""), nil
+}
+
+// Write a genesis transaction in a file
+func writeGenTx(cdc *codec.Codec, outputDocument string, stdTx auth.StdTx) error {
+	if err := os.MkdirAll(filepath.Dir(outputDocument), 0700); err != nil {
+		return err
+	}
+
+	bz, err := cdc.MarshalJSON(stdTx)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(outputDocument, sdk.MustSortJSON(bz), 0600)
+}
@@ -0,0 +1,129 @@
+package cli_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/ltacker/poa"
+	poacli "github.com/ltacker/poa/client/cli"
+	"github.com/ltacker/poa/types"
+)
+
+// Sign the genesis transaction of a new validator with a new operator key and write it in the directory
+func writeMockGenTx(t *testing.T, cdc *codec.Codec, kb keys.Keybase, name string, chainID string, genTxsDir string) types.Validator {
+	// The key has no passphrase as in a keyring, SignGenTx signs without passphrase
+	armor := mintkey.EncryptArmorPrivKey(secp256k1.GenPrivKey(), "", string(keys.Secp256k1))
+	if err := kb.ImportPrivKey(name, armor, ""); err != nil {
+		t.Fatalf("Cannot create the key of %v: %v", name, err)
+	}
+	info, _ := kb.Get(name)
+
+	mock, _ := poa.MockValidator()
+	validator := types.NewValidator(sdk.ValAddress(info.GetAddress()), mock.GetConsPubKey(), types.Description{Moniker: name})
+	stdTx, err := poacli.SignGenTx(kb, name, chainID, types.NewMsgSubmitApplication(validator))
+	if err != nil {
+		t.Fatalf("SignGenTx should sign the genesis transaction of %v, got error %v", name, err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(genTxsDir, "gentx-"+name+".json"), cdc.MustMarshalJSON(stdTx), 0600); err != nil {
+		t.Fatalf("Cannot write the genesis transaction of %v: %v", name, err)
+	}
+
+	return validator
+}
+
+func TestGenTxs(t *testing.T) {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	home := setupGenesisHome(t, cdc)
+	defer os.RemoveAll(home)
+
+	genTxsDir := filepath.Join(home, "config", "gentx")
+	if err := os.MkdirAll(genTxsDir, 0700); err != nil {
+		t.Fatalf("Cannot create the gentx directory: %v", err)
+	}
+
+	// Each member signs its genesis transaction with its operator key
+	kb := keys.NewInMemory()
+	validator1 := writeMockGenTx(t, cdc, kb, "operator1", "poa-chain", genTxsDir)
+	writeMockGenTx(t, cdc, kb, "operator2", "poa-chain", genTxsDir)
+
+	// The genesis transactions are verified for the chain
+	validators, err := poacli.CollectGenTxs(cdc, "poa-chain", genTxsDir)
+	if err != nil {
+		t.Fatalf("CollectGenTxs should verify the genesis transactions, got error %v", err)
+	}
+	if len(validators) != 2 {
+		t.Fatalf("CollectGenTxs should return 2 validators, got %v", len(validators))
+	}
+	if _, err := poacli.CollectGenTxs(cdc, "other-chain", genTxsDir); err == nil {
+		t.Errorf("CollectGenTxs should not verify the genesis transactions signed for another chain")
+	}
+
+	// A genesis transaction modified after its signature is invalid
+	bz, err := ioutil.ReadFile(filepath.Join(genTxsDir, "gentx-operator1.json"))
+	if err != nil {
+		t.Fatalf("Cannot read the genesis transaction of operator 1: %v", err)
+	}
+	var stdTx auth.StdTx
+	cdc.MustUnmarshalJSON(bz, &stdTx)
+	msg := stdTx.Msgs[0].(types.MsgSubmitApplication)
+	msg.Candidate.Description.Moniker = "modified"
+	modifiedTx := auth.NewStdTx([]sdk.Msg{msg}, stdTx.Fee, stdTx.Signatures, stdTx.Memo)
+	if _, err := poacli.ValidateGenTx("poa-chain", modifiedTx); err == nil {
+		t.Errorf("ValidateGenTx should not verify a modified genesis transaction")
+	}
+
+	// A genesis transaction without signature or with a signature without public key is invalid
+	noSignatureTx := auth.NewStdTx(stdTx.Msgs, stdTx.Fee, nil, stdTx.Memo)
+	if _, err := poacli.ValidateGenTx("poa-chain", noSignatureTx); err == nil {
+		t.Errorf("ValidateGenTx should not verify a genesis transaction without signature")
+	}
+	noPubKeyTx := auth.NewStdTx(stdTx.Msgs, stdTx.Fee, []auth.StdSignature{{Signature: stdTx.Signatures[0].Signature}}, stdTx.Memo)
+	if _, err := poacli.ValidateGenTx("poa-chain", noPubKeyTx); err == nil {
+		t.Errorf("ValidateGenTx should not verify a genesis transaction with a signature without public key")
+	}
+
+	// A genesis transaction must be signed by the operator
+	mock, _ := poa.MockValidator()
+	otherOperator := types.NewMsgSubmitApplication(types.NewValidator(mock.GetOperator(), mock.GetConsPubKey(), mock.GetDescription()))
+	stdTx, _ = poacli.SignGenTx(kb, "operator1", "poa-chain", otherOperator)
+	if _, err := poacli.ValidateGenTx("poa-chain", stdTx); err == nil {
+		t.Errorf("ValidateGenTx should not verify a genesis transaction not signed by the operator")
+	}
+
+	// The coordinator appends the validators to the genesis state
+	collectGenTxs := func(ctx *server.Context, cdc *codec.Codec, home string, _ string) *cobra.Command {
+		return poacli.CollectGenTxsCmd(ctx, cdc, home)
+	}
+	if err := runGenesisCmd(cdc, collectGenTxs, home); err != nil {
+		t.Fatalf("collect-gentxs should append the validators to the genesis state, got error %v", err)
+	}
+	genesis := genesisValidators(t, cdc, home)
+	if len(genesis) != 2 {
+		t.Fatalf("The genesis state should contain 2 validators, got %v", len(genesis))
+	}
+	if !genesis[0].GetOperator().Equals(validator1.GetOperator()) {
+		t.Errorf("The genesis state should contain the validator of operator 1 first, got %v", genesis[0])
+	}
+
+	// The validators cannot be collected twice
+	if err := runGenesisCmd(cdc, collectGenTxs, home); err == nil {
+		t.Errorf("collect-gentxs should not append the same validators twice")
+	}
+}
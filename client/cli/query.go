@@ -30,19 +30,107 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			GetCmdQueryValidator(queryRoute, cdc),
 			GetCmdQueryValidators(queryRoute, cdc),
 			GetCmdQueryParams(queryRoute, cdc),
+			GetCmdQueryApplication(queryRoute, cdc),
 			GetCmdQueryApplications(queryRoute, cdc),
+			GetCmdQueryKickProposal(queryRoute, cdc),
 			GetCmdQueryKickProposals(queryRoute, cdc),
+			GetCmdQueryRewards(queryRoute, cdc),
+			GetCmdQueryWithdrawAddress(queryRoute, cdc),
+			GetCmdQueryProposalHistory(queryRoute, cdc),
+			GetCmdQueryParticipation(queryRoute, cdc),
+			GetCmdWatch(queryRoute, cdc),
 		)...,
 	)
 
 	return poaQueryCmd
 }
 
+// Get the query data to find a validator or a candidate by operator address, consensus address or consensus public key
+// The endpoint querying by consensus address is returned for a consensus address or public key
+func addressQueryData(cdc *codec.Codec, bech32Str string, endpoint string, consAddrEndpoint string) (string, []byte, error) {
+	var params interface{}
+	if types.IsConsensusBech32(bech32Str) {
+		consAddr, err := types.ConsAddressFromBech32(bech32Str)
+		if err != nil {
+			return "", nil, err
+		}
+		endpoint = consAddrEndpoint
+		params = types.NewQueryConsAddrParams(consAddr)
+	} else {
+		addr, err := sdk.ValAddressFromBech32(bech32Str)
+		if err != nil {
+			return "", nil, err
+		}
+		params = types.NewQueryValidatorParams(addr)
+	}
+
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return endpoint, bz, nil
+}
+
 // GetCmdQueryValidator queries information about a validator
 func GetCmdQueryValidator(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "validator [validator-addr]",
-		Short: "Query a validator",
+		Use:   "validator [validator-addr|cons-addr|cons-pubkey]",
+		Short: "Query a validator by operator address, consensus address or consensus public key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			endpoint, bz, err := addressQueryData(cdc, args[0], types.QueryValidator, types.QueryValidatorByConsAddr)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, endpoint), bz)
+			if err != nil {
+				fmt.Printf("could not resolve %s %s \n", endpoint, args[0])
+				return nil
+			}
+
+			var out types.ValidatorWithState
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryApplication queries the application of a candidate
+func GetCmdQueryApplication(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "application [candidate-addr|cons-addr|cons-pubkey]",
+		Short: "Query the tally of an application by operator address, consensus address or consensus public key of the candidate",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			endpoint, bz, err := addressQueryData(cdc, args[0], types.QueryApplication, types.QueryApplicationByConsAddr)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, endpoint), bz)
+			if err != nil {
+				fmt.Printf("could not resolve %s %s \n", endpoint, args[0])
+				return nil
+			}
+
+			var out types.Tally
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryKickProposal queries the kick proposal of a validator
+func GetCmdQueryKickProposal(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "kick-proposal [candidate-addr]",
+		Short: "Query the tally of the kick proposal of a validator",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
@@ -53,7 +141,6 @@ func GetCmdQueryValidator(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			// We don't want pagination for cli queries
 			params := types.NewQueryValidatorParams(addr)
 
 			bz, err := cdc.MarshalJSON(params)
@@ -61,13 +148,13 @@ func GetCmdQueryValidator(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryValidator), bz)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryKickProposal), bz)
 			if err != nil {
-				fmt.Printf("could not resolve %s %s \n", types.QueryValidator, addr)
+				fmt.Printf("could not resolve %s %s \n", types.QueryKickProposal, addr)
 				return nil
 			}
 
-			var out types.Validator
+			var out types.Tally
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
@@ -76,24 +163,40 @@ func GetCmdQueryValidator(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 // GetCmdQueryValidators queries all validators
 func GetCmdQueryValidators(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "validators",
 		Short: "Query all validators",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryValidators), nil)
+			page, _ := cmd.Flags().GetInt(FlagPage)
+			limit, _ := cmd.Flags().GetInt(FlagLimit)
+			status, _ := cmd.Flags().GetString(FlagStatus)
+			sortBy, _ := cmd.Flags().GetString(FlagSortBy)
+			params := types.NewQueryListParams(page, limit, status, sortBy)
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryValidators), bz)
 			if err != nil {
 				fmt.Printf("could not resolve %s \n", types.QueryValidators)
 				return nil
 			}
 
-			var out []types.Validator
+			var out []types.ValidatorWithState
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
 	}
+
+	cmd.Flags().AddFlagSet(FlagSetList())
+	cmd.Flags().String(FlagStatus, "", "Only return the validators with this state (joining|joined|leaving|jailing|jailed)")
+
+	return cmd
 }
 
 // GetCmdQueryParams queries the params
@@ -120,14 +223,24 @@ func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 // GetCmdQueryApplications queries the applications to become a validator
 func GetCmdQueryApplications(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "applications",
 		Short: "Query the applications to become validator",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryApplications), nil)
+			page, _ := cmd.Flags().GetInt(FlagPage)
+			limit, _ := cmd.Flags().GetInt(FlagLimit)
+			sortBy, _ := cmd.Flags().GetString(FlagSortBy)
+			params := types.NewQueryListParams(page, limit, "", sortBy)
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryApplications), bz)
 			if err != nil {
 				fmt.Printf("could not resolve %s \n", types.QueryApplications)
 				return nil
@@ -138,18 +251,32 @@ func GetCmdQueryApplications(queryRoute string, cdc *codec.Codec) *cobra.Command
 			return cliCtx.PrintOutput(out)
 		},
 	}
+
+	cmd.Flags().AddFlagSet(FlagSetList())
+
+	return cmd
 }
 
 // GetCmdQueryKickProposals queries the kick proposals to remove a validator
 func GetCmdQueryKickProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "kick-proposals",
 		Short: "Query the kick proposals to remove validator",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryKickProposals), nil)
+			page, _ := cmd.Flags().GetInt(FlagPage)
+			limit, _ := cmd.Flags().GetInt(FlagLimit)
+			sortBy, _ := cmd.Flags().GetString(FlagSortBy)
+			params := types.NewQueryListParams(page, limit, "", sortBy)
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryKickProposals), bz)
 			if err != nil {
 				fmt.Printf("could not resolve %s \n", types.QueryKickProposals)
 				return nil
@@ -160,4 +287,162 @@ func GetCmdQueryKickProposals(queryRoute string, cdc *codec.Codec) *cobra.Comman
 			return cliCtx.PrintOutput(out)
 		},
 	}
+
+	cmd.Flags().AddFlagSet(FlagSetList())
+
+	return cmd
+}
+
+// GetCmdQueryRewards queries the rewards of a validator
+func GetCmdQueryRewards(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewards [validator-addr]",
+		Short: "Query the rewards of a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Get address
+			addr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := types.NewQueryValidatorParams(addr)
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryRewards), bz)
+			if err != nil {
+				fmt.Printf("could not resolve %s %s \n", types.QueryRewards, addr)
+				return nil
+			}
+
+			var out sdk.Coins
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryWithdrawAddress queries the address receiving the rewards of a validator
+func GetCmdQueryWithdrawAddress(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw-address [validator-addr]",
+		Short: "Query the address receiving the rewards of a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Get address
+			addr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := types.NewQueryValidatorParams(addr)
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryWithdrawAddress), bz)
+			if err != nil {
+				fmt.Printf("could not resolve %s %s \n", types.QueryWithdrawAddress, addr)
+				return nil
+			}
+
+			var out sdk.AccAddress
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryProposalHistory queries the closed applications and kick proposals
+func GetCmdQueryProposalHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposal-history",
+		Short: "Query the closed applications and kick proposals with their outcome",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			page, _ := cmd.Flags().GetInt(FlagPage)
+			limit, _ := cmd.Flags().GetInt(FlagLimit)
+			outcome, _ := cmd.Flags().GetString(FlagOutcome)
+
+			var candidate sdk.ValAddress
+			if candidateStr, _ := cmd.Flags().GetString(FlagCandidate); candidateStr != "" {
+				var err error
+				candidate, err = sdk.ValAddressFromBech32(candidateStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			params := types.NewQueryProposalHistoryParams(page, limit, candidate, outcome)
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryProposalHistory), bz)
+			if err != nil {
+				fmt.Printf("could not resolve %s \n", types.QueryProposalHistory)
+				return nil
+			}
+
+			var out []types.ClosedProposal
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().Int(FlagPage, 1, "The page of the results")
+	cmd.Flags().Int(FlagLimit, 0, "The number of results per page, all the results are returned if 0")
+	cmd.Flags().String(FlagCandidate, "", "Only the proposals of the candidate with this operator address")
+	cmd.Flags().String(FlagOutcome, "", "Only the proposals with this outcome (approved|rejected|expired|withdrawn)")
+
+	return cmd
+}
+
+// GetCmdQueryParticipation queries the participation of a validator in the votes
+func GetCmdQueryParticipation(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "participation [validator-addr]",
+		Short: "Query the participation of a validator in the votes on the applications and the kick proposals",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Get address
+			addr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := types.NewQueryValidatorParams(addr)
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryParticipation), bz)
+			if err != nil {
+				fmt.Printf("could not resolve %s %s \n", types.QueryParticipation, addr)
+				return nil
+			}
+
+			var out types.Participation
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
 }
@@ -2,7 +2,10 @@ package cli
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -31,7 +34,10 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		GetCmdProposeKick(cdc),
 		GetCmdVoteApplication(cdc),
 		GetCmdVoteKickProposal(cdc),
+		GetCmdVoteBatch(cdc),
 		GetCmdLeaveValidatorSet(cdc),
+		GetCmdSetWithdrawAddress(cdc),
+		GetCmdWithdrawRewards(cdc),
 	)...)
 
 	return poaTxCmd
@@ -211,6 +217,101 @@ func GetCmdVoteKickProposal(cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// A decision of the JSON file of the vote-batch command
+type batchVoteDecision struct {
+	Type      string `json:"type"`
+	Candidate string `json:"candidate"`
+	Vote      string `json:"vote"`
+}
+
+// Read the votes of a batch from a JSON file of decisions
+func readBatchVotes(path string) ([]types.BatchVote, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var decisions []batchVoteDecision
+	if err := json.Unmarshal(bz, &decisions); err != nil {
+		return nil, err
+	}
+
+	votes := make([]types.BatchVote, len(decisions))
+	for i, decision := range decisions {
+		var voteType uint16
+		switch decision.Type {
+		case "application":
+			voteType = types.VoteTypeApplication
+		case "kick-proposal":
+			voteType = types.VoteTypeKickProposal
+		default:
+			return nil, fmt.Errorf("decision %d: type neither application nor kick-proposal", i)
+		}
+
+		candidateAddr, err := sdk.ValAddressFromBech32(decision.Candidate)
+		if err != nil {
+			return nil, fmt.Errorf("decision %d: %v", i, err)
+		}
+
+		var approved bool
+		if decision.Vote == "approve" {
+			approved = true
+		} else if decision.Vote == "reject" {
+			approved = false
+		} else {
+			return nil, fmt.Errorf("decision %d: vote neither approved nor rejected", i)
+		}
+
+		votes[i] = types.NewBatchVote(voteType, candidateAddr, approved)
+	}
+
+	return votes, nil
+}
+
+// GetCmdVoteBatch approves or rejects several applications and kick proposals in a single message
+func GetCmdVoteBatch(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vote-batch [decisions-file]",
+		Short: "Approve or reject several applications and kick proposals in a single message",
+		Long: strings.TrimSpace(`Approve or reject several applications and kick proposals in a single message.
+The message fails and no vote is performed if one of the votes fails.
+
+The decisions are read from a JSON file:
+
+[
+  {"type": "application", "candidate": "cosmosvaloper1...", "vote": "approve"},
+  {"type": "kick-proposal", "candidate": "cosmosvaloper1...", "vote": "reject"}
+]
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			// Voter address is the sender
+			accAddress := cliCtx.GetFromAddress()
+			if accAddress.Empty() {
+				return fmt.Errorf("Account address empty")
+			}
+			voterAddress := sdk.ValAddress(accAddress)
+
+			votes, err := readBatchVotes(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgVoteBatch(voterAddress, votes)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 // GetCmdLeaveValidatorSet remove oneself from the validator set
 func GetCmdLeaveValidatorSet(cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -238,3 +339,66 @@ func GetCmdLeaveValidatorSet(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdSetWithdrawAddress sets the address receiving the rewards of the validator
+func GetCmdSetWithdrawAddress(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-withdraw-address [withdraw-addr]",
+		Short: "Set the address receiving the rewards of the validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			// Validator address is the sender
+			accAddress := cliCtx.GetFromAddress()
+			if accAddress.Empty() {
+				return fmt.Errorf("Account address empty")
+			}
+			validatorAddress := sdk.ValAddress(accAddress)
+
+			withdrawAddress, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSetWithdrawAddress(validatorAddress, withdrawAddress)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdWithdrawRewards sends the rewards of the validator to its withdraw address
+func GetCmdWithdrawRewards(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "withdraw-rewards",
+		Short: "Withdraw the rewards of the validator to its withdraw address",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			// Validator address is the sender
+			accAddress := cliCtx.GetFromAddress()
+			if accAddress.Empty() {
+				return fmt.Errorf("Account address empty")
+			}
+			validatorAddress := sdk.ValAddress(accAddress)
+
+			msg := types.NewMsgWithdrawRewards(validatorAddress)
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
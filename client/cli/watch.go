@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ltacker/poa/types"
+)
+
+const (
+	FlagJSON         = "json"
+	FlagForValidator = "for-validator"
+
+	// Name of the subscriber of the watch command in the node
+	watchSubscriber = "poa-watch"
+)
+
+// WatchedEvent is a poa event emitted by the node
+// The transaction hash is empty for the events emitted during begin block and end block
+type WatchedEvent struct {
+	Height int64           `json:"height"`
+	TxHash string          `json:"txhash,omitempty"`
+	Event  sdk.StringEvent `json:"event"`
+}
+
+// Get the value of an attribute of the event
+func (e WatchedEvent) Attribute(key string) (string, bool) {
+	for _, attribute := range e.Event.Attributes {
+		if attribute.Key == key {
+			return attribute.Value, true
+		}
+	}
+	return "", false
+}
+
+// String implements the Stringer interface
+func (e WatchedEvent) String() string {
+	attributes := make([]string, len(e.Event.Attributes))
+	for i, attribute := range e.Event.Attributes {
+		attributes[i] = fmt.Sprintf("%s=%s", attribute.Key, attribute.Value)
+	}
+
+	out := fmt.Sprintf("height=%d %s %s", e.Height, e.Event.Type, strings.Join(attributes, " "))
+	if e.TxHash != "" {
+		out += fmt.Sprintf(" txhash=%s", e.TxHash)
+	}
+	return out
+}
+
+// PoaEvents returns the poa events contained in an event received from a subscription
+// The events are taken from the results of the transactions and of begin block and end block
+// Each event is converted on its own in the order it has been emitted, the events of the same type are not merged
+func PoaEvents(result ctypes.ResultEvent) []WatchedEvent {
+	var height int64
+	var txHash string
+	var events []abci.Event
+
+	switch data := result.Data.(type) {
+	case tmtypes.EventDataTx:
+		height = data.Height
+		txHash = fmt.Sprintf("%X", tmtypes.Tx(data.Tx).Hash())
+		events = data.Result.Events
+	case tmtypes.EventDataNewBlockHeader:
+		height = data.Header.Height
+		events = append(append(events, data.ResultBeginBlock.Events...), data.ResultEndBlock.Events...)
+	}
+
+	var poaEvents []WatchedEvent
+	for _, event := range events {
+		watched := WatchedEvent{
+			Height: height,
+			TxHash: txHash,
+			Event:  sdk.StringifyEvent(event),
+		}
+		if module, _ := watched.Attribute(sdk.AttributeKeyModule); module == types.AttributeValueCategory {
+			poaEvents = append(poaEvents, watched)
+		}
+	}
+
+	return poaEvents
+}
+
+// WatchEvents subscribes to the transactions and the blocks of the node and calls handle for each poa event
+// It returns when the context is done or when handle returns an error
+func WatchEvents(ctx context.Context, client rpcclient.EventsClient, handle func(WatchedEvent) error) error {
+	txs, err := client.Subscribe(ctx, watchSubscriber, tmtypes.EventQueryTx.String())
+	if err != nil {
+		return err
+	}
+	defer client.UnsubscribeAll(context.Background(), watchSubscriber)
+
+	blocks, err := client.Subscribe(ctx, watchSubscriber, tmtypes.EventQueryNewBlockHeader.String())
+	if err != nil {
+		return err
+	}
+
+	for {
+		var result ctypes.ResultEvent
+		var ok bool
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case result, ok = <-txs:
+		case result, ok = <-blocks:
+		}
+		if !ok {
+			return fmt.Errorf("subscription to the node closed")
+		}
+
+		for _, event := range PoaEvents(result) {
+			if err := handle(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Get the query endpoint of the proposal concerned by an event and the address of its candidate
+// The event doesn't concern an open proposal if found is false
+func proposalOfEvent(event WatchedEvent) (endpoint string, candidate string, found bool) {
+	switch event.Event.Type {
+	case types.EventTypeSubmitApplication, types.EventTypeApproveApplication, types.EventTypeRejectApplication:
+		endpoint = types.QueryApplication
+		candidate, found = event.Attribute(types.AttributeKeyCandidate)
+	case types.EventTypeProposeKick, types.EventTypeApproveKickProposal, types.EventTypeRejectKickProposal:
+		endpoint = types.QueryKickProposal
+		candidate, found = event.Attribute(types.AttributeKeyValidator)
+	}
+	return endpoint, candidate, found
+}
+
+// PendingVoteFilter returns a filter keeping only the events of the open proposals on which the voter can still vote
+// queryTally returns the tally of a proposal from its query endpoint and the address of its candidate
+func PendingVoteFilter(voter sdk.ValAddress, queryTally func(endpoint string, candidate sdk.ValAddress) (types.Tally, error)) func(WatchedEvent) bool {
+	return func(event WatchedEvent) bool {
+		endpoint, candidateStr, found := proposalOfEvent(event)
+		if !found {
+			return false
+		}
+		candidate, err := sdk.ValAddressFromBech32(candidateStr)
+		if err != nil {
+			return false
+		}
+
+		// The proposal is closed if the tally cannot be found
+		tally, err := queryTally(endpoint, candidate)
+		if err != nil {
+			return false
+		}
+
+		for _, remainingVoter := range tally.RemainingVoters {
+			if remainingVoter.Equals(voter) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// GetCmdWatch streams the poa events emitted by the node
+func GetCmdWatch(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Stream the poa events emitted by the node as they happen",
+		Long: strings.TrimSpace(`Stream the poa events emitted by the node as they happen.
+
+With --for-validator, only the events of the applications and kick proposals on which
+the validator of the key provided with --from has not voted yet are shown.
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := clientcontext.NewCLIContext().WithCodec(cdc)
+
+			client, err := cliCtx.GetNode()
+			if err != nil {
+				return err
+			}
+
+			// Filter the events if a voter is provided
+			filter := func(WatchedEvent) bool { return true }
+			if forValidator, _ := cmd.Flags().GetBool(FlagForValidator); forValidator {
+				accAddress := cliCtx.GetFromAddress()
+				if accAddress.Empty() {
+					return fmt.Errorf("Account address empty")
+				}
+
+				filter = PendingVoteFilter(sdk.ValAddress(accAddress), func(endpoint string, candidate sdk.ValAddress) (types.Tally, error) {
+					var tally types.Tally
+
+					bz, err := cdc.MarshalJSON(types.NewQueryValidatorParams(candidate))
+					if err != nil {
+						return tally, err
+					}
+
+					res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, endpoint), bz)
+					if err != nil {
+						return tally, err
+					}
+
+					err = cdc.UnmarshalJSON(res, &tally)
+					return tally, err
+				})
+			}
+
+			// The websocket connection is opened when the client is started
+			if !client.IsRunning() {
+				if err := client.Start(); err != nil {
+					return err
+				}
+				defer client.Stop()
+			}
+
+			// Stop watching on interruption
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			signals := make(chan os.Signal, 1)
+			signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+			go func() {
+				<-signals
+				cancel()
+			}()
+
+			jsonOutput, _ := cmd.Flags().GetBool(FlagJSON)
+			return WatchEvents(ctx, client, func(event WatchedEvent) error {
+				if !filter(event) {
+					return nil
+				}
+				return printWatchedEvent(cmd.OutOrStdout(), event, jsonOutput)
+			})
+		},
+	}
+
+	cmd.Flags().Bool(FlagJSON, false, "Print each event as a JSON object on a single line")
+	cmd.Flags().Bool(FlagForValidator, false, "Only show the proposals on which the validator of the --from key has not voted yet")
+	cmd.Flags().String(flags.FlagFrom, "", "Name or address of the key of the validator used with --for-validator")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	viper.BindPFlag(flags.FlagKeyringBackend, cmd.Flags().Lookup(flags.FlagKeyringBackend))
+
+	return cmd
+}
+
+// Print a watched event as text or as a JSON object on a single line
+func printWatchedEvent(w io.Writer, event WatchedEvent, jsonOutput bool) error {
+	if !jsonOutput {
+		_, err := fmt.Fprintln(w, event.String())
+		return err
+	}
+
+	bz, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(bz))
+	return err
+}
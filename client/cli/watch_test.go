@@ -0,0 +1,245 @@
+package cli_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/client/cli"
+	"github.com/ltacker/poa/types"
+)
+
+// mockEventsClient is an events client publishing the events sent to its channels
+type mockEventsClient struct {
+	txs          chan ctypes.ResultEvent
+	blocks       chan ctypes.ResultEvent
+	unsubscribed bool
+}
+
+func newMockEventsClient() *mockEventsClient {
+	return &mockEventsClient{
+		txs:    make(chan ctypes.ResultEvent, 10),
+		blocks: make(chan ctypes.ResultEvent, 10),
+	}
+}
+
+func (c *mockEventsClient) Subscribe(_ context.Context, _, query string, _ ...int) (<-chan ctypes.ResultEvent, error) {
+	switch query {
+	case tmtypes.EventQueryTx.String():
+		return c.txs, nil
+	case tmtypes.EventQueryNewBlockHeader.String():
+		return c.blocks, nil
+	default:
+		return nil, fmt.Errorf("unexpected query %v", query)
+	}
+}
+
+func (c *mockEventsClient) Unsubscribe(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (c *mockEventsClient) UnsubscribeAll(_ context.Context, _ string) error {
+	c.unsubscribed = true
+	return nil
+}
+
+// Create an abci event as emitted by the handler
+func mockEvent(eventType string, module string, attributes ...sdk.Attribute) abci.Event {
+	return abci.Event(sdk.NewEvent(
+		eventType,
+		append([]sdk.Attribute{sdk.NewAttribute(sdk.AttributeKeyModule, module)}, attributes...)...,
+	))
+}
+
+func TestWatchEvents(t *testing.T) {
+	client := newMockEventsClient()
+	candidate := poa.MockValAddress()
+	validator := poa.MockValAddress()
+
+	// A transaction with a poa event and an event from another module
+	client.txs <- ctypes.ResultEvent{
+		Data: tmtypes.EventDataTx{TxResult: tmtypes.TxResult{
+			Height: 5,
+			Tx:     []byte("tx"),
+			Result: abci.ResponseDeliverTx{Events: []abci.Event{
+				mockEvent(types.EventTypeSubmitApplication, types.ModuleName, sdk.NewAttribute(types.AttributeKeyCandidate, candidate.String())),
+				mockEvent("transfer", "bank"),
+			}},
+		}},
+	}
+
+	// A block with a poa event during end block
+	client.blocks <- ctypes.ResultEvent{
+		Data: tmtypes.EventDataNewBlockHeader{
+			Header: tmtypes.Header{Height: 6},
+			ResultEndBlock: abci.ResponseEndBlock{Events: []abci.Event{
+				mockEvent(types.EventTypeValidatorJoined, types.ModuleName, sdk.NewAttribute(types.AttributeKeyValidator, validator.String())),
+			}},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var watched []cli.WatchedEvent
+	err := cli.WatchEvents(ctx, client, func(event cli.WatchedEvent) error {
+		watched = append(watched, event)
+		if len(watched) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WatchEvents should return without error when the context is done, got %v", err)
+	}
+	if !client.unsubscribed {
+		t.Errorf("WatchEvents should unsubscribe from the node")
+	}
+	if len(watched) != 2 {
+		t.Fatalf("WatchEvents should watch 2 poa events, got %v", len(watched))
+	}
+
+	// The events can be received in any order
+	for _, event := range watched {
+		switch event.Event.Type {
+		case types.EventTypeSubmitApplication:
+			if event.Height != 5 || event.TxHash != fmt.Sprintf("%X", tmtypes.Tx("tx").Hash()) {
+				t.Errorf("The transaction event should have the height and the hash of the transaction, got %v", event)
+			}
+			if value, _ := event.Attribute(types.AttributeKeyCandidate); value != candidate.String() {
+				t.Errorf("The transaction event should have the candidate %v, got %v", candidate, value)
+			}
+		case types.EventTypeValidatorJoined:
+			if event.Height != 6 || event.TxHash != "" {
+				t.Errorf("The end block event should have the height of the block and no hash, got %v", event)
+			}
+		default:
+			t.Errorf("WatchEvents should only watch poa events, got %v", event)
+		}
+	}
+
+	// An error from the handle function stops watching
+	client.blocks <- ctypes.ResultEvent{
+		Data: tmtypes.EventDataNewBlockHeader{
+			Header: tmtypes.Header{Height: 7},
+			ResultEndBlock: abci.ResponseEndBlock{Events: []abci.Event{
+				mockEvent(types.EventTypeValidatorRemoved, types.ModuleName),
+			}},
+		},
+	}
+	err = cli.WatchEvents(context.Background(), client, func(event cli.WatchedEvent) error {
+		return fmt.Errorf("handle error")
+	})
+	if err == nil || err.Error() != "handle error" {
+		t.Errorf("WatchEvents should return the error of the handle function, got %v", err)
+	}
+}
+
+func TestWatchEventsSameType(t *testing.T) {
+	client := newMockEventsClient()
+	candidate1 := poa.MockValAddress()
+	candidate2 := poa.MockValAddress()
+
+	// A batch vote emits an event of the same type for each candidate
+	client.txs <- ctypes.ResultEvent{
+		Data: tmtypes.EventDataTx{TxResult: tmtypes.TxResult{
+			Height: 5,
+			Tx:     []byte("tx"),
+			Result: abci.ResponseDeliverTx{Events: []abci.Event{
+				mockEvent(types.EventTypeApproveApplication, types.ModuleName, sdk.NewAttribute(types.AttributeKeyCandidate, candidate2.String())),
+				mockEvent(types.EventTypeApproveApplication, types.ModuleName, sdk.NewAttribute(types.AttributeKeyCandidate, candidate1.String())),
+			}},
+		}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var watched []cli.WatchedEvent
+	err := cli.WatchEvents(ctx, client, func(event cli.WatchedEvent) error {
+		watched = append(watched, event)
+		if len(watched) == 1 {
+			// The events of the transaction are handled before the events of the next block
+			client.blocks <- ctypes.ResultEvent{
+				Data: tmtypes.EventDataNewBlockHeader{
+					Header: tmtypes.Header{Height: 6},
+					ResultEndBlock: abci.ResponseEndBlock{Events: []abci.Event{
+						mockEvent(types.EventTypeValidatorJoined, types.ModuleName, sdk.NewAttribute(types.AttributeKeyValidator, candidate1.String())),
+					}},
+				},
+			}
+		}
+		if event.Height == 6 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WatchEvents should return without error when the context is done, got %v", err)
+	}
+
+	// The events are not merged and keep their order
+	if len(watched) != 3 || watched[0].Height != 5 || watched[1].Height != 5 {
+		t.Fatalf("WatchEvents should watch 2 separate events in the transaction, got %v", watched)
+	}
+	for i, candidate := range []sdk.ValAddress{candidate2, candidate1} {
+		if len(watched[i].Event.Attributes) != 2 {
+			t.Errorf("The event %v should only have its own attributes, got %v", i, watched[i].Event.Attributes)
+		}
+		if value, _ := watched[i].Attribute(types.AttributeKeyCandidate); value != candidate.String() {
+			t.Errorf("The event %v should have the candidate %v, got %v", i, candidate, value)
+		}
+	}
+}
+
+func TestPendingVoteFilter(t *testing.T) {
+	voter := poa.MockValAddress()
+	other := poa.MockValAddress()
+	pendingCandidate := poa.MockValAddress()
+	votedCandidate := poa.MockValAddress()
+
+	// Only the application of the pending candidate still waits the vote of the voter
+	filter := cli.PendingVoteFilter(voter, func(endpoint string, candidate sdk.ValAddress) (types.Tally, error) {
+		if endpoint != types.QueryApplication {
+			return types.Tally{}, fmt.Errorf("no kick proposal")
+		}
+		if candidate.Equals(pendingCandidate) {
+			return types.Tally{RemainingVoters: []sdk.ValAddress{other, voter}}, nil
+		}
+		return types.Tally{RemainingVoters: []sdk.ValAddress{other}}, nil
+	})
+
+	application := func(eventType string, candidate sdk.ValAddress) cli.WatchedEvent {
+		return cli.WatchedEvent{Event: sdk.StringifyEvent(mockEvent(
+			eventType,
+			types.ModuleName,
+			sdk.NewAttribute(types.AttributeKeyCandidate, candidate.String()),
+		))}
+	}
+
+	if !filter(application(types.EventTypeSubmitApplication, pendingCandidate)) {
+		t.Errorf("The filter should keep an application on which the voter has not voted")
+	}
+	if !filter(application(types.EventTypeApproveApplication, pendingCandidate)) {
+		t.Errorf("The filter should keep the votes on an application on which the voter has not voted")
+	}
+	if filter(application(types.EventTypeSubmitApplication, votedCandidate)) {
+		t.Errorf("The filter should not keep an application on which the voter has voted")
+	}
+	if filter(application(types.EventTypeAppendValidator, pendingCandidate)) {
+		t.Errorf("The filter should not keep an event not concerning an open proposal")
+	}
+
+	kickProposal := cli.WatchedEvent{Event: sdk.StringifyEvent(mockEvent(
+		types.EventTypeProposeKick,
+		types.ModuleName,
+		sdk.NewAttribute(types.AttributeKeyValidator, pendingCandidate.String()),
+	))}
+	if filter(kickProposal) {
+		t.Errorf("The filter should not keep a proposal that cannot be found")
+	}
+}
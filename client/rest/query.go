@@ -3,38 +3,238 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
 	"github.com/ltacker/poa/types"
 )
 
 func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
-	// TODO: Define your GET REST endpoints
 	r.HandleFunc(
 		"/poa/parameters",
-		queryParamsHandlerFn(cliCtx),
+		queryHandlerFn(cliCtx, types.QueryParams),
 	).Methods("GET")
+	r.HandleFunc(
+		"/poa/validators",
+		queryListHandlerFn(cliCtx, types.QueryValidators),
+	).Methods("GET")
+	r.HandleFunc(
+		fmt.Sprintf("/poa/validators/{%s}", RestValidatorAddr),
+		queryAddressHandlerFn(cliCtx, RestValidatorAddr, types.QueryValidator, types.QueryValidatorByConsAddr),
+	).Methods("GET")
+	r.HandleFunc(
+		fmt.Sprintf("/poa/validators/{%s}/rewards", RestValidatorAddr),
+		queryValidatorParamsHandlerFn(cliCtx, types.QueryRewards),
+	).Methods("GET")
+	r.HandleFunc(
+		fmt.Sprintf("/poa/validators/{%s}/withdraw-address", RestValidatorAddr),
+		queryValidatorParamsHandlerFn(cliCtx, types.QueryWithdrawAddress),
+	).Methods("GET")
+	r.HandleFunc(
+		fmt.Sprintf("/poa/validators/{%s}/participation", RestValidatorAddr),
+		queryValidatorParamsHandlerFn(cliCtx, types.QueryParticipation),
+	).Methods("GET")
+	r.HandleFunc(
+		"/poa/applications",
+		queryListHandlerFn(cliCtx, types.QueryApplications),
+	).Methods("GET")
+	r.HandleFunc(
+		fmt.Sprintf("/poa/applications/{%s}", RestCandidateAddr),
+		queryAddressHandlerFn(cliCtx, RestCandidateAddr, types.QueryApplication, types.QueryApplicationByConsAddr),
+	).Methods("GET")
+	r.HandleFunc(
+		"/poa/kick-proposals",
+		queryListHandlerFn(cliCtx, types.QueryKickProposals),
+	).Methods("GET")
+	r.HandleFunc(
+		fmt.Sprintf("/poa/kick-proposals/{%s}", RestCandidateAddr),
+		queryOperatorHandlerFn(cliCtx, RestCandidateAddr, types.QueryKickProposal),
+	).Methods("GET")
+	r.HandleFunc(
+		"/poa/proposal-history",
+		queryProposalHistoryHandlerFn(cliCtx),
+	).Methods("GET")
+}
+
+// Query a poa querier endpoint without parameters
+func queryHandlerFn(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, endpoint)
+		writeQueryResponse(w, cliCtx, route, nil)
+	}
+}
+
+// Parse the page and limit query parameters, the first page with all the results by default
+func parsePagination(w http.ResponseWriter, r *http.Request) (page int, limit int, ok bool) {
+	page = 1
+	if v := r.FormValue("page"); v != "" {
+		var err error
+		page, err = strconv.Atoi(v)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return 0, 0, false
+		}
+	}
+	if v := r.FormValue("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return 0, 0, false
+		}
+	}
+
+	return page, limit, true
+}
+
+// Query a poa list endpoint with the page, limit, status and sort_by query parameters
+func queryListHandlerFn(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		page, limit, ok := parsePagination(w, r)
+		if !ok {
+			return
+		}
+		params := types.NewQueryListParams(page, limit, r.FormValue("status"), r.FormValue("sort_by"))
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, endpoint)
+		writeQueryResponse(w, cliCtx, route, bz)
+	}
+}
+
+// Query a validator or a candidate by operator address (valoper...), consensus address (valcons...) or consensus public key (valconspub...)
+// The endpoint querying by consensus address is used for a consensus address or public key
+func queryAddressHandlerFn(cliCtx context.CLIContext, addrVar string, endpoint string, consAddrEndpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		bech32Addr := mux.Vars(r)[addrVar]
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, endpoint)
+		var params interface{}
+		if types.IsConsensusBech32(bech32Addr) {
+			consAddr, err := types.ConsAddressFromBech32(bech32Addr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			route = fmt.Sprintf("custom/%s/%s", types.QuerierRoute, consAddrEndpoint)
+			params = types.NewQueryConsAddrParams(consAddr)
+		} else {
+			valAddr, err := sdk.ValAddressFromBech32(bech32Addr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			params = types.NewQueryValidatorParams(valAddr)
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		writeQueryResponse(w, cliCtx, route, bz)
+	}
+}
+
+// Query the closed proposals with the page, limit, candidate and outcome query parameters
+func queryProposalHistoryHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		page, limit, ok := parsePagination(w, r)
+		if !ok {
+			return
+		}
+
+		var candidate sdk.ValAddress
+		if v := r.FormValue("candidate"); v != "" {
+			var err error
+			candidate, err = sdk.ValAddressFromBech32(v)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		params := types.NewQueryProposalHistoryParams(page, limit, candidate, r.FormValue("outcome"))
+
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, types.QueryProposalHistory)
+		writeQueryResponse(w, cliCtx, route, bz)
+	}
 }
 
-func queryParamsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+// Query a poa querier endpoint taking an operator address
+func queryValidatorParamsHandlerFn(cliCtx context.CLIContext, endpoint string) http.HandlerFunc {
+	return queryOperatorHandlerFn(cliCtx, RestValidatorAddr, endpoint)
+}
+
+// Query a poa querier endpoint taking the operator address found in the addrVar path variable
+func queryOperatorHandlerFn(cliCtx context.CLIContext, addrVar string, endpoint string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
 		if !ok {
 			return
 		}
 
-		route := fmt.Sprintf("custom/%s/parameters", types.QuerierRoute)
+		valAddr, err := sdk.ValAddressFromBech32(mux.Vars(r)[addrVar])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
-		res, height, err := cliCtx.QueryWithData(route, nil)
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryValidatorParams(valAddr))
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		cliCtx = cliCtx.WithHeight(height)
-		rest.PostProcessResponse(w, cliCtx, res)
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, endpoint)
+		writeQueryResponse(w, cliCtx, route, bz)
+	}
+}
+
+// Run the query and write its result with the height of the query
+func writeQueryResponse(w http.ResponseWriter, cliCtx context.CLIContext, route string, data []byte) {
+	res, height, err := cliCtx.QueryWithData(route, data)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
 	}
+
+	cliCtx = cliCtx.WithHeight(height)
+	rest.PostProcessResponse(w, cliCtx, res)
 }
@@ -6,6 +6,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 )
 
+// Path variables of the REST routes
+const (
+	RestCandidateAddr = "candidateAddr"
+	RestValidatorAddr = "validatorAddr"
+)
+
 // RegisterRoutes registers poa-related REST handlers to a router
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	registerQueryRoutes(cliCtx, r)
@@ -1,45 +1,276 @@
 package rest
-// The packages below are commented out at first to prevent an error if this file isn't initially saved.
+
 import (
-	// "bytes"
-	// "net/http"
+	"fmt"
+	"net/http"
 
 	"github.com/gorilla/mux"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
-	// sdk "github.com/cosmos/cosmos-sdk/types"
-	// "github.com/cosmos/cosmos-sdk/types/rest"
-	// "github.com/cosmos/cosmos-sdk/x/auth/client/utils"
-	// "github.com/ltacker/poa/x/poa/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/ltacker/poa/types"
 )
 
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
-	// r.HandleFunc(
-	// TODO: Define the Rest route ,
-	// Call the function which should be executed for this route),
-	// ).Methods("POST")
+	r.HandleFunc(
+		"/poa/applications",
+		postSubmitApplicationHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		fmt.Sprintf("/poa/applications/{%s}/votes", RestCandidateAddr),
+		postVoteHandlerFn(cliCtx, types.VoteTypeApplication),
+	).Methods("POST")
+	r.HandleFunc(
+		"/poa/kick-proposals",
+		postProposeKickHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		fmt.Sprintf("/poa/kick-proposals/{%s}/votes", RestCandidateAddr),
+		postVoteHandlerFn(cliCtx, types.VoteTypeKickProposal),
+	).Methods("POST")
+	r.HandleFunc(
+		"/poa/vote-batch",
+		postVoteBatchHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/poa/leave-validator-set",
+		postLeaveValidatorSetHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/poa/withdraw-address",
+		postSetWithdrawAddressHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/poa/withdraw-rewards",
+		postWithdrawRewardsHandlerFn(cliCtx),
+	).Methods("POST")
+}
+
+type (
+	// SubmitApplicationReq defines the properties of an application request's body
+	// The operator address of the candidate is the sender
+	SubmitApplicationReq struct {
+		BaseReq         rest.BaseReq      `json:"base_req" yaml:"base_req"`
+		ConsensusPubkey string            `json:"consensus_pubkey" yaml:"consensus_pubkey"`
+		Description     types.Description `json:"description" yaml:"description"`
+	}
+
+	// VoteReq defines the properties of a vote request's body
+	VoteReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+		Approve bool         `json:"approve" yaml:"approve"`
+	}
+
+	// VoteBatchReq defines the properties of a vote batch request's body
+	VoteBatchReq struct {
+		BaseReq rest.BaseReq      `json:"base_req" yaml:"base_req"`
+		Votes   []types.BatchVote `json:"votes" yaml:"votes"`
+	}
+
+	// ProposeKickReq defines the properties of a kick proposal request's body
+	ProposeKickReq struct {
+		BaseReq       rest.BaseReq   `json:"base_req" yaml:"base_req"`
+		CandidateAddr sdk.ValAddress `json:"candidate_address" yaml:"candidate_address"`
+	}
+
+	// LeaveValidatorSetReq defines the properties of a leave validator set request's body
+	LeaveValidatorSetReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	}
+
+	// SetWithdrawAddressReq defines the properties of a set withdraw address request's body
+	SetWithdrawAddressReq struct {
+		BaseReq      rest.BaseReq   `json:"base_req" yaml:"base_req"`
+		WithdrawAddr sdk.AccAddress `json:"withdraw_address" yaml:"withdraw_address"`
+	}
+
+	// WithdrawRewardsReq defines the properties of a withdraw rewards request's body
+	WithdrawRewardsReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+	}
+)
+
+// Get the validator address of the sender of the request
+func senderValAddress(w http.ResponseWriter, baseReq rest.BaseReq) (sdk.ValAddress, bool) {
+	fromAddr, err := sdk.AccAddressFromBech32(baseReq.From)
+	if err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	return sdk.ValAddress(fromAddr), true
+}
+
+// Validate the message, then write the unsigned transaction containing it
+func writeGenerateStdTxResponse(w http.ResponseWriter, cliCtx context.CLIContext, baseReq rest.BaseReq, msg sdk.Msg) {
+	if err := msg.ValidateBasic(); err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
 }
 
-/*
-// Action TX body
-type <Action>Req struct {
-	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
-	// TODO: Define more types if needed
+func postSubmitApplicationHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SubmitApplicationReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		opAddress, ok := senderValAddress(w, req.BaseReq)
+		if !ok {
+			return
+		}
+
+		// Consensus public key for the validator
+		pk, err := sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeConsPub, req.ConsensusPubkey)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("cannot convert pubkey: %v", err))
+			return
+		}
+
+		candidateValidator := types.NewValidator(opAddress, pk, req.Description)
+
+		writeGenerateStdTxResponse(w, cliCtx, req.BaseReq, types.NewMsgSubmitApplication(candidateValidator))
+	}
 }
 
-func <Action>RequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+func postVoteHandlerFn(cliCtx context.CLIContext, voteType uint16) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req <Action>Req
-		vars := mux.Vars(r)
+		var req VoteReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		voterAddress, ok := senderValAddress(w, req.BaseReq)
+		if !ok {
+			return
+		}
+
+		candidateAddr, err := sdk.ValAddressFromBech32(mux.Vars(r)[RestCandidateAddr])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		writeGenerateStdTxResponse(w, cliCtx, req.BaseReq, types.NewMsgVote(voteType, voterAddress, candidateAddr, req.Approve))
+	}
+}
 
-		baseReq := req.BaseReq.Sanitize()
-		if !baseReq.ValidateBasic(w) {
+func postVoteBatchHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req VoteBatchReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
 			return
 		}
 
-		// TODO: Define the module tx logic for this action
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		voterAddress, ok := senderValAddress(w, req.BaseReq)
+		if !ok {
+			return
+		}
+
+		writeGenerateStdTxResponse(w, cliCtx, req.BaseReq, types.NewMsgVoteBatch(voterAddress, req.Votes))
+	}
+}
+
+func postProposeKickHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ProposeKickReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		proposerAddress, ok := senderValAddress(w, req.BaseReq)
+		if !ok {
+			return
+		}
+
+		writeGenerateStdTxResponse(w, cliCtx, req.BaseReq, types.NewMsgProposeKick(req.CandidateAddr, proposerAddress))
+	}
+}
+
+func postLeaveValidatorSetHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req LeaveValidatorSetReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		validatorAddress, ok := senderValAddress(w, req.BaseReq)
+		if !ok {
+			return
+		}
+
+		writeGenerateStdTxResponse(w, cliCtx, req.BaseReq, types.NewMsgLeaveValidatorSet(validatorAddress))
+	}
+}
+
+func postSetWithdrawAddressHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SetWithdrawAddressReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		validatorAddress, ok := senderValAddress(w, req.BaseReq)
+		if !ok {
+			return
+		}
+
+		writeGenerateStdTxResponse(w, cliCtx, req.BaseReq, types.NewMsgSetWithdrawAddress(validatorAddress, req.WithdrawAddr))
+	}
+}
+
+func postWithdrawRewardsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req WithdrawRewardsReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		validatorAddress, ok := senderValAddress(w, req.BaseReq)
+		if !ok {
+			return
+		}
 
-		utils.WriteGenerateStdTxResponse(w, cliCtx, BaseReq, []sdk.Msg{msg})
+		writeGenerateStdTxResponse(w, cliCtx, req.BaseReq, types.NewMsgWithdrawRewards(validatorAddress))
 	}
 }
-*/
@@ -0,0 +1,245 @@
+// Package example shows how to wire the poa module in an application
+// with the slashing and evidence modules running on top of the poa validator set
+package example
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/evidence"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	sim "github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/ltacker/poa"
+	poakeeper "github.com/ltacker/poa/keeper"
+	poatypes "github.com/ltacker/poa/types"
+)
+
+const appName = "PoaExampleApp"
+
+var (
+	// ModuleBasics defines the module BasicManager of the application
+	ModuleBasics = module.NewBasicManager(
+		auth.AppModuleBasic{},
+		supply.AppModuleBasic{},
+		bank.AppModuleBasic{},
+		params.AppModuleBasic{},
+		slashing.AppModuleBasic{},
+		evidence.AppModuleBasic{},
+		poa.AppModuleBasic{},
+	)
+
+	// module account permissions
+	maccPerms = map[string][]string{
+		auth.FeeCollectorName: nil,
+		poatypes.ModuleName:   {supply.Minter},
+	}
+)
+
+// MakeCodec creates the application codec
+func MakeCodec() *codec.Codec {
+	var cdc = codec.New()
+	ModuleBasics.RegisterCodec(cdc)
+	vesting.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	return cdc
+}
+
+// GenesisState of the application
+type GenesisState map[string]json.RawMessage
+
+// NewDefaultGenesisState generates the default state for the application
+func NewDefaultGenesisState() GenesisState {
+	return ModuleBasics.DefaultGenesis()
+}
+
+// ExampleApp is an application using poa to determine the validator set
+type ExampleApp struct {
+	*bam.BaseApp
+	cdc *codec.Codec
+
+	keys  map[string]*sdk.KVStoreKey
+	tkeys map[string]*sdk.TransientStoreKey
+
+	AccountKeeper  auth.AccountKeeper
+	BankKeeper     bank.Keeper
+	SupplyKeeper   supply.Keeper
+	ParamsKeeper   params.Keeper
+	SlashingKeeper slashing.Keeper
+	EvidenceKeeper evidence.Keeper
+	PoaKeeper      poakeeper.Keeper
+
+	mm *module.Manager
+	sm *module.SimulationManager
+}
+
+// NewExampleApp returns a reference to an initialized ExampleApp
+func NewExampleApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp)) *ExampleApp {
+	cdc := MakeCodec()
+
+	bApp := bam.NewBaseApp(appName, logger, db, auth.DefaultTxDecoder(cdc), baseAppOptions...)
+
+	keys := sdk.NewKVStoreKeys(
+		bam.MainStoreKey, auth.StoreKey, supply.StoreKey, params.StoreKey,
+		slashing.StoreKey, evidence.StoreKey, poatypes.StoreKey,
+	)
+	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
+
+	app := &ExampleApp{
+		BaseApp: bApp,
+		cdc:     cdc,
+		keys:    keys,
+		tkeys:   tkeys,
+	}
+
+	app.ParamsKeeper = params.NewKeeper(app.cdc, keys[params.StoreKey], tkeys[params.TStoreKey])
+	authSubspace := app.ParamsKeeper.Subspace(auth.DefaultParamspace)
+	bankSubspace := app.ParamsKeeper.Subspace(bank.DefaultParamspace)
+	slashingSubspace := app.ParamsKeeper.Subspace(slashing.DefaultParamspace)
+	evidenceSubspace := app.ParamsKeeper.Subspace(evidence.DefaultParamspace)
+	poaSubspace := app.ParamsKeeper.Subspace(poatypes.ModuleName)
+
+	app.AccountKeeper = auth.NewAccountKeeper(app.cdc, keys[auth.StoreKey], authSubspace, auth.ProtoBaseAccount)
+	app.BankKeeper = bank.NewBaseKeeper(app.AccountKeeper, bankSubspace, map[string]bool{})
+	app.SupplyKeeper = supply.NewKeeper(app.cdc, keys[supply.StoreKey], app.AccountKeeper, app.BankKeeper, maccPerms)
+
+	// The poa keeper is used as the staking validator set of slashing and evidence
+	poaKeeper := poakeeper.NewKeeper(app.cdc, keys[poatypes.StoreKey], poaSubspace, app.SupplyKeeper, auth.FeeCollectorName)
+	app.SlashingKeeper = slashing.NewKeeper(app.cdc, keys[slashing.StoreKey], &poaKeeper, slashingSubspace)
+
+	evidenceKeeper := evidence.NewKeeper(app.cdc, keys[evidence.StoreKey], evidenceSubspace, &poaKeeper, app.SlashingKeeper)
+	evidenceKeeper.SetRouter(evidence.NewRouter())
+	app.EvidenceKeeper = *evidenceKeeper
+
+	// The slashing hooks keep track of the validators appended and removed by poa
+	// NOTE: poaKeeper above is passed by reference, so that it will contain these hooks
+	app.PoaKeeper = *poaKeeper.SetHooks(
+		poatypes.NewMultiPoaHooks(poakeeper.NewStakingHooks(app.SlashingKeeper.Hooks())),
+	)
+
+	app.mm = module.NewManager(
+		auth.NewAppModule(app.AccountKeeper),
+		bank.NewAppModule(app.BankKeeper, app.AccountKeeper),
+		supply.NewAppModule(app.SupplyKeeper, app.AccountKeeper),
+		newSlashingAppModule(app.SlashingKeeper, app.AccountKeeper, app.PoaKeeper),
+		evidence.NewAppModule(app.EvidenceKeeper),
+		poa.NewAppModule(app.PoaKeeper, app.AccountKeeper),
+	)
+
+	// Slashing jails the validators during begin block, poa removes them from Tendermint validator set during end block
+	app.mm.SetOrderBeginBlockers(poatypes.ModuleName, slashing.ModuleName, evidence.ModuleName)
+	app.mm.SetOrderEndBlockers(poatypes.ModuleName)
+
+	// poa must be initialized before slashing so that slashing finds the genesis validators
+	app.mm.SetOrderInitGenesis(
+		auth.ModuleName, bank.ModuleName, supply.ModuleName,
+		poatypes.ModuleName, slashing.ModuleName, evidence.ModuleName,
+	)
+
+	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
+
+	// The supply is computed from the simulated accounts, the slashing module only generates its genesis state
+	app.sm = module.NewSimulationManager(
+		auth.NewAppModule(app.AccountKeeper),
+		bank.NewAppModule(app.BankKeeper, app.AccountKeeper),
+		newSlashingAppModule(app.SlashingKeeper, app.AccountKeeper, app.PoaKeeper),
+		poa.NewAppModule(app.PoaKeeper, app.AccountKeeper),
+	)
+	app.sm.RegisterStoreDecoders()
+
+	app.MountKVStores(keys)
+	app.MountTransientStores(tkeys)
+
+	app.SetInitChainer(app.InitChainer)
+	app.SetBeginBlocker(app.BeginBlocker)
+	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.SupplyKeeper, auth.DefaultSigVerificationGasConsumer))
+	app.SetEndBlocker(app.EndBlocker)
+
+	if err := app.LoadLatestVersion(app.keys[bam.MainStoreKey]); err != nil {
+		panic(err)
+	}
+
+	return app
+}
+
+// BeginBlocker application updates every begin block
+func (app *ExampleApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	return app.mm.BeginBlock(ctx, req)
+}
+
+// EndBlocker application updates every end block
+func (app *ExampleApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+	return app.mm.EndBlock(ctx, req)
+}
+
+// InitChainer application update at chain initialization
+func (app *ExampleApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+	var genesisState GenesisState
+	app.cdc.MustUnmarshalJSON(req.AppStateBytes, &genesisState)
+	return app.mm.InitGenesis(ctx, genesisState)
+}
+
+// Codec returns the codec of the application
+func (app *ExampleApp) Codec() *codec.Codec {
+	return app.cdc
+}
+
+// ModuleAccountAddrs returns all the module account addresses of the application
+func (app *ExampleApp) ModuleAccountAddrs() map[string]bool {
+	modAccAddrs := make(map[string]bool)
+	for acc := range maccPerms {
+		modAccAddrs[supply.NewModuleAddress(acc).String()] = true
+	}
+
+	return modAccAddrs
+}
+
+// SimulationManager returns the simulation manager of the application
+func (app *ExampleApp) SimulationManager() *module.SimulationManager {
+	return app.sm
+}
+
+// slashingAppModule is the slashing module initialized from the poa validator set
+// The slashing module expects a staking keeper to initialize its genesis, the poa keeper is used instead
+type slashingAppModule struct {
+	slashing.AppModule
+
+	keeper    slashing.Keeper
+	poaKeeper poakeeper.Keeper
+}
+
+func newSlashingAppModule(keeper slashing.Keeper, accountKeeper auth.AccountKeeper, poaKeeper poakeeper.Keeper) slashingAppModule {
+	return slashingAppModule{
+		AppModule: slashing.NewAppModule(keeper, accountKeeper, staking.Keeper{}),
+		keeper:    keeper,
+		poaKeeper: poaKeeper,
+	}
+}
+
+// InitGenesis performs genesis initialization for the slashing module from the poa validator set
+func (am slashingAppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	var genesisState slashing.GenesisState
+	slashing.ModuleCdc.MustUnmarshalJSON(data, &genesisState)
+	slashing.InitGenesis(ctx, am.keeper, am.poaKeeper, genesisState)
+	return []abci.ValidatorUpdate{}
+}
+
+// WeightedOperations returns no operation, the slashing operations expect a staking keeper
+func (am slashingAppModule) WeightedOperations(_ module.SimulationState) []sim.WeightedOperation {
+	return nil
+}
@@ -0,0 +1,147 @@
+package example_test
+
+import (
+	"testing"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/example"
+	"github.com/ltacker/poa/types"
+)
+
+// Start a chain with the provided poa validators and a short slashing window
+func setupApp(t *testing.T, validators []types.Validator) *example.ExampleApp {
+	app := example.NewExampleApp(log.NewNopLogger(), dbm.NewMemDB())
+	cdc := app.Codec()
+
+	genesisState := example.NewDefaultGenesisState()
+	genesisState[types.ModuleName] = cdc.MustMarshalJSON(types.NewGenesisState(types.DefaultParams(), validators))
+
+	// A validator missing more than half of the 10 last blocks is jailed
+	slashingParams := slashing.NewParams(10, sdk.NewDecWithPrec(5, 1), time.Minute, sdk.ZeroDec(), sdk.ZeroDec())
+	genesisState[slashing.ModuleName] = cdc.MustMarshalJSON(slashing.NewGenesisState(slashingParams, nil, nil))
+
+	stateBytes, err := cdc.MarshalJSONIndent(genesisState, "", " ")
+	if err != nil {
+		t.Fatalf("Cannot marshal genesis state: %v", err)
+	}
+
+	res := app.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+	if len(res.Validators) != len(validators) {
+		t.Fatalf("InitChain should return %v validators, got %v", len(validators), len(res.Validators))
+	}
+	app.Commit()
+
+	return app
+}
+
+// Run a block where the provided validators sign or not the previous block
+func runBlock(app *example.ExampleApp, height int64, signing map[string]bool, validators []types.Validator) abci.ResponseEndBlock {
+	var votes []abci.VoteInfo
+	for _, validator := range validators {
+		votes = append(votes, abci.VoteInfo{
+			Validator: abci.Validator{
+				Address: validator.GetConsAddr(),
+				Power:   1,
+			},
+			SignedLastBlock: signing[validator.GetConsAddr().String()],
+		})
+	}
+
+	app.BeginBlock(abci.RequestBeginBlock{
+		Header:         abci.Header{Height: height, Time: time.Unix(height, 0)},
+		LastCommitInfo: abci.LastCommitInfo{Votes: votes},
+	})
+	res := app.EndBlock(abci.RequestEndBlock{Height: height})
+	app.Commit()
+
+	return res
+}
+
+func TestSlashingJailsPoaValidator(t *testing.T) {
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	validators := []types.Validator{validator1, validator2}
+	app := setupApp(t, validators)
+
+	// Validator 2 never signs
+	signing := map[string]bool{
+		validator1.GetConsAddr().String(): true,
+		validator2.GetConsAddr().String(): false,
+	}
+
+	var jailedAt int64
+	for height := int64(2); height <= 30 && jailedAt == 0; height++ {
+		res := runBlock(app, height, signing, validators)
+
+		for _, update := range res.ValidatorUpdates {
+			if update.Power == 0 && update.PubKey.Equal(validator2.ABCIValidatorUpdateRemove().PubKey) {
+				jailedAt = height
+			}
+		}
+	}
+
+	if jailedAt == 0 {
+		t.Fatalf("Validator 2 should be removed from Tendermint validator set after missing blocks")
+	}
+
+	// The validator remains in the poa validator set with the jailed state
+	ctx := app.BaseApp.NewContext(true, abci.Header{})
+	state, found := app.PoaKeeper.GetValidatorState(ctx, validator2.GetOperator())
+	if !found {
+		t.Fatalf("A jailed validator should remain in the poa validator set")
+	}
+	if state != types.ValidatorStateJailed {
+		t.Errorf("A jailed validator should have the jailed state, got %v", state)
+	}
+	if !app.PoaKeeper.ValidatorByConsAddr(ctx, validator2.GetConsAddr()).IsJailed() {
+		t.Errorf("A jailed validator should be jailed for slashing")
+	}
+
+	// Validator 1 is still in Tendermint validator set
+	state, _ = app.PoaKeeper.GetValidatorState(ctx, validator1.GetOperator())
+	if state != types.ValidatorStateJoined {
+		t.Errorf("A signing validator should keep the joined state, got %v", state)
+	}
+}
+
+func TestRemovedValidatorLeavesSlashing(t *testing.T) {
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	validators := []types.Validator{validator1, validator2}
+	app := setupApp(t, validators)
+	signing := map[string]bool{
+		validator1.GetConsAddr().String(): true,
+		validator2.GetConsAddr().String(): true,
+	}
+	runBlock(app, 2, signing, validators)
+
+	// Validator 2 leaves the validator set at the end of block 3
+	header := abci.Header{Height: 3, Time: time.Unix(3, 0)}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	app.PoaKeeper.SetValidatorState(app.BaseApp.NewContext(false, header), validator2, types.ValidatorStateLeaving)
+	app.EndBlock(abci.RequestEndBlock{Height: 3})
+	app.Commit()
+
+	// Validator 2 signs the blocks 3 and 4 in the last commit of the blocks 4 and 5
+	runBlock(app, 4, signing, validators)
+	ctx := app.BaseApp.NewContext(true, abci.Header{})
+	if _, err := app.SlashingKeeper.GetPubkey(ctx, validator2.GetConsAddr().Bytes()); err != nil {
+		t.Errorf("Slashing should keep the pubkey of a removed validator still in the last commit")
+	}
+	runBlock(app, 5, signing, validators)
+
+	// Validator 2 is no longer in the last commit
+	ctx = app.BaseApp.NewContext(true, abci.Header{})
+	if _, err := app.SlashingKeeper.GetPubkey(ctx, validator2.GetConsAddr().Bytes()); err == nil {
+		t.Errorf("Slashing should delete the pubkey of a removed validator no longer in the last commit")
+	}
+	runBlock(app, 6, signing, []types.Validator{validator1})
+}
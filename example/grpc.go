@@ -0,0 +1,39 @@
+package example
+
+import (
+	"context"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ltacker/poa"
+	poatypes "github.com/ltacker/poa/types"
+)
+
+// NewGRPCServer returns a gRPC server serving the Query service of the poa module
+// Each call runs on a branch of the last committed state that is discarded
+// The Msg service is not served, the transactions are delivered with amino through the ante handler
+func (app *ExampleApp) NewGRPCServer() *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(app.grpcContextInterceptor))
+	app.mm.Modules[poatypes.ModuleName].(poa.AppModule).RegisterServices(server)
+
+	return server
+}
+
+// Provide the sdk.Context of the last committed state to a gRPC call
+func (app *ExampleApp) grpcContextInterceptor(goCtx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	ctx = ctx.WithMultiStore(ctx.MultiStore().CacheMultiStore()).WithContext(goCtx)
+
+	// A panic of the handler must not stop the node
+	defer func() {
+		if r := recover(); r != nil {
+			err = status.Error(codes.Internal, fmt.Sprintf("%v", r))
+		}
+	}()
+
+	return handler(poatypes.WrapSDKContext(ctx), req)
+}
@@ -0,0 +1,52 @@
+package example_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/types"
+	"github.com/ltacker/poa/types/pb"
+)
+
+func TestGRPCServer(t *testing.T) {
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	app := setupApp(t, []types.Validator{validator1, validator2})
+
+	// Serve the poa services in memory
+	listener := bufconn.Listen(1024 * 1024)
+	server := app.NewGRPCServer()
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	if err != nil {
+		t.Fatalf("Cannot dial the gRPC server: %v", err)
+	}
+	defer conn.Close()
+	ctx := context.Background()
+
+	// The queries read the last committed state
+	res, err := pb.NewQueryClient(conn).Validators(ctx, &pb.QueryValidatorsRequest{})
+	if err != nil {
+		t.Fatalf("Validators should return the validators, got error %v", err)
+	}
+	if len(res.Validators) != 2 {
+		t.Errorf("Validators should return 2 validators, got %v", len(res.Validators))
+	}
+
+	// The Msg service is not served
+	_, err = pb.NewMsgClient(conn).LeaveValidatorSet(ctx, &pb.MsgLeaveValidatorSet{ValidatorAddr: validator1.GetOperator()})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("LeaveValidatorSet should not be served, got error %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package example_test
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/ltacker/poa/example"
+	"github.com/ltacker/poa/keeper"
+	poasim "github.com/ltacker/poa/simulation"
+	"github.com/ltacker/poa/types"
+)
+
+func TestAppSimulation(t *testing.T) {
+	for _, seed := range []int64{1, 7, 42} {
+		app := example.NewExampleApp(log.NewNopLogger(), dbm.NewMemDB())
+		config := simulation.Config{
+			Seed:      seed,
+			NumBlocks: 30,
+			BlockSize: 30,
+			ChainID:   helpers.SimAppChainID,
+			Commit:    true,
+		}
+
+		// The applications, kick proposals, votes and departures of the validators are randomly delivered
+		simState := module.SimulationState{AppParams: make(simulation.AppParams), Cdc: app.Codec()}
+		_, _, err := simulation.SimulateFromSeed(
+			t, ioutil.Discard, app.BaseApp, simapp.AppStateFn(app.Codec(), app.SimulationManager()),
+			app.SimulationManager().WeightedOperations(simState),
+			app.ModuleAccountAddrs(), config,
+		)
+		if err != nil {
+			t.Fatalf("The simulation with seed %v should not fail, got error %v", seed, err)
+		}
+
+		ctx := app.BaseApp.NewContext(true, abci.Header{})
+		if msg, broken := keeper.AllInvariants(app.PoaKeeper)(ctx); broken {
+			t.Errorf("The simulation with seed %v should not break the poa invariants: %v", seed, msg)
+		}
+	}
+}
+
+func TestSimulationCatchesPanic(t *testing.T) {
+	app := example.NewExampleApp(log.NewNopLogger(), dbm.NewMemDB())
+	cdc := app.Codec()
+
+	// The account of the simulation is the only genesis validator
+	accs := simulation.RandomAccounts(rand.New(rand.NewSource(1)), 2)
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000000))
+	genesisState := example.NewDefaultGenesisState()
+	genesisState[auth.ModuleName] = cdc.MustMarshalJSON(auth.NewGenesisState(auth.DefaultParams(), authexported.GenesisAccounts{
+		auth.NewBaseAccount(accs[0].Address, coins, accs[0].PubKey, 0, 0),
+	}))
+	validator := types.NewValidator(sdk.ValAddress(accs[0].Address), accs[0].PubKey, types.Description{Moniker: "validator"})
+	genesisState[types.ModuleName] = cdc.MustMarshalJSON(types.NewGenesisState(types.DefaultParams(), []types.Validator{validator}))
+	app.InitChain(abci.RequestInitChain{ChainId: helpers.SimAppChainID, AppStateBytes: cdc.MustMarshalJSON(genesisState)})
+	app.Commit()
+
+	// A validator is stored without state
+	header := abci.Header{ChainID: helpers.SimAppChainID, Height: 2}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := app.BaseApp.NewContext(false, header)
+	app.PoaKeeper.SetValidator(ctx, types.NewValidator(sdk.ValAddress(accs[1].Address), accs[1].PubKey, types.Description{Moniker: "stateless"}))
+
+	// The kick proposal against the validator without state panics in the handler and fails the operation
+	op := poasim.SimulateMsgProposeKick(app.AccountKeeper, app.PoaKeeper)
+	var err error
+	for seed := int64(0); seed < 10 && err == nil; seed++ {
+		_, _, err = op(rand.New(rand.NewSource(seed)), app.BaseApp, ctx, accs[:1], helpers.SimAppChainID)
+	}
+	if err == nil || !strings.Contains(err.Error(), "A validator has no state") {
+		t.Errorf("The simulation should fail with the panic of the handler, got %v", err)
+	}
+}
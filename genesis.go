@@ -1,6 +1,8 @@
 package poa
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ltacker/poa/keeper"
 	"github.com/ltacker/poa/types"
@@ -11,13 +13,47 @@ import (
 // and the keeper's address to pubkey map
 func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) (res []abci.ValidatorUpdate) {
 	k.SetParams(ctx, data.Params)
+	k.SetConsensusVersion(ctx, types.ConsensusVersion)
+
+	states := make(map[string]uint16, len(data.ValidatorStates))
+	for _, state := range data.ValidatorStates {
+		states[state.OperatorAddress.String()] = state.State
+	}
 
 	// Set validators in the storage
 	for _, validator := range data.Validators {
 		k.SetValidator(ctx, validator)
 		k.SetValidatorByConsAddr(ctx, validator)
-		k.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
-		res = append(res, validator.ABCIValidatorUpdateAppend())
+
+		state, found := states[validator.GetOperator().String()]
+		if !found || state == types.ValidatorStateJoined {
+			k.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+			k.AfterValidatorAppended(ctx, validator.GetOperator())
+			k.AfterValidatorJoined(ctx, validator.GetConsAddr(), validator.GetOperator())
+			res = append(res, validator.ABCIValidatorUpdateAppend())
+			continue
+		}
+
+		// A jailed or leaving validator is not part of Tendermint validator set
+		k.SetValidatorState(ctx, validator, state)
+		k.SetValidatorOutOfSet(ctx, validator.GetOperator())
+		k.AfterValidatorAppended(ctx, validator.GetOperator())
+	}
+
+	// Set the rewards and the withdraw addresses of the validators
+	totalRewards := sdk.NewCoins()
+	for _, entry := range data.Rewards {
+		k.SetValidatorRewards(ctx, entry.OperatorAddress, entry.Rewards)
+		totalRewards = totalRewards.Add(entry.Rewards...)
+	}
+	for _, entry := range data.WithdrawAddresses {
+		k.SetWithdrawAddress(ctx, entry.OperatorAddress, entry.WithdrawAddress)
+	}
+
+	// The poa module account holds the rewards of the validators
+	balance, distributed := k.GetRewardsPoolBalance(ctx)
+	if distributed && !(balance.IsAllGTE(totalRewards) && totalRewards.IsAllGTE(balance)) {
+		panic(fmt.Sprintf("poa module account balance %s does not match the sum of the validator rewards %s", balance, totalRewards))
 	}
 
 	return res
@@ -27,8 +63,43 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) (res
 // to a genesis file, which can be imported again
 // with InitGenesis
 func ExportGenesis(ctx sdk.Context, k keeper.Keeper) (data types.GenesisState) {
+	validators := k.GetAllValidators(ctx)
+
+	var states []types.GenesisValidatorState
+	for _, validator := range validators {
+		state, found := k.GetValidatorState(ctx, validator.GetOperator())
+		if !found || state == types.ValidatorStateJoined {
+			continue
+		}
+
+		// The pending changes of Tendermint validator set are applied in the genesis
+		switch state {
+		case types.ValidatorStateJoining:
+			continue
+		case types.ValidatorStateJailing:
+			state = types.ValidatorStateJailed
+		}
+		states = append(states, types.NewGenesisValidatorState(validator.GetOperator(), state))
+	}
+
+	var rewards []types.GenesisValidatorRewards
+	var withdrawAddresses []types.GenesisWithdrawAddress
+	for _, validator := range validators {
+		if validatorRewards := k.GetValidatorRewards(ctx, validator.GetOperator()); !validatorRewards.IsZero() {
+			rewards = append(rewards, types.NewGenesisValidatorRewards(validator.GetOperator(), validatorRewards))
+		}
+
+		// The operator account is the default withdraw address
+		if withdrawAddr := k.GetWithdrawAddress(ctx, validator.GetOperator()); !withdrawAddr.Equals(sdk.AccAddress(validator.GetOperator())) {
+			withdrawAddresses = append(withdrawAddresses, types.NewGenesisWithdrawAddress(validator.GetOperator(), withdrawAddr))
+		}
+	}
+
 	return types.GenesisState{
-		Params:     k.GetParams(ctx),
-		Validators: k.GetAllValidators(ctx),
+		Params:            k.GetParams(ctx),
+		Validators:        validators,
+		ValidatorStates:   states,
+		Rewards:           rewards,
+		WithdrawAddresses: withdrawAddresses,
 	}
 }
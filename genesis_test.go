@@ -26,6 +26,47 @@ func TestValidateGenesis(t *testing.T) {
 		t.Errorf("The genesis state %v should not be valid", invalidGenesis)
 	}
 
+	// A genesis with two validators with the same operator address is invalid
+	other, _ := poa.MockValidator()
+	sameOperator := types.NewValidator(validator.GetOperator(), other.GetConsPubKey(), other.GetDescription())
+	invalidGenesis = types.NewGenesisState(types.DefaultParams(), []types.Validator{validator, sameOperator})
+	if types.ValidateGenesis(invalidGenesis) == nil {
+		t.Errorf("The genesis state %v with the same operator twice should not be valid", invalidGenesis)
+	}
+
+	// A genesis with more validators than the maximum is invalid
+	invalidGenesis = types.NewGenesisState(types.NewParams(1, types.DefaultQuorum), []types.Validator{validator, other})
+	if types.ValidateGenesis(invalidGenesis) == nil {
+		t.Errorf("The genesis state %v with more validators than the maximum should not be valid", invalidGenesis)
+	}
+
+	// A genesis with the state of an unknown validator is invalid
+	invalidGenesis = types.NewGenesisState(types.DefaultParams(), []types.Validator{validator})
+	invalidGenesis.ValidatorStates = []types.GenesisValidatorState{types.NewGenesisValidatorState(other.GetOperator(), types.ValidatorStateJailed)}
+	if types.ValidateGenesis(invalidGenesis) == nil {
+		t.Errorf("The genesis state %v with the state of an unknown validator should not be valid", invalidGenesis)
+	}
+
+	// A genesis with a pending state is invalid
+	invalidGenesis.ValidatorStates = []types.GenesisValidatorState{types.NewGenesisValidatorState(validator.GetOperator(), types.ValidatorStateJailing)}
+	if types.ValidateGenesis(invalidGenesis) == nil {
+		t.Errorf("The genesis state %v with a jailing validator should not be valid", invalidGenesis)
+	}
+
+	// A genesis with the rewards of an unknown validator is invalid
+	invalidGenesis = types.NewGenesisState(types.DefaultParams(), []types.Validator{validator})
+	invalidGenesis.Rewards = []types.GenesisValidatorRewards{types.NewGenesisValidatorRewards(other.GetOperator(), sdk.NewCoins(sdk.NewInt64Coin("stake", 10)))}
+	if types.ValidateGenesis(invalidGenesis) == nil {
+		t.Errorf("The genesis state %v with the rewards of an unknown validator should not be valid", invalidGenesis)
+	}
+
+	// A genesis with an empty withdraw address is invalid
+	invalidGenesis = types.NewGenesisState(types.DefaultParams(), []types.Validator{validator})
+	invalidGenesis.WithdrawAddresses = []types.GenesisWithdrawAddress{types.NewGenesisWithdrawAddress(validator.GetOperator(), nil)}
+	if types.ValidateGenesis(invalidGenesis) == nil {
+		t.Errorf("The genesis state %v with an empty withdraw address should not be valid", invalidGenesis)
+	}
+
 	// Default genesis state
 	if types.ValidateGenesis(types.DefaultGenesisState()) != nil {
 		t.Errorf("The default genesis state should be valid")
@@ -64,6 +105,82 @@ func TestInitGenesis(t *testing.T) {
 	}
 }
 
+func TestInitGenesisJailedValidator(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator, _ := poa.MockValidator()
+	jailed, _ := poa.MockValidator()
+
+	testGenesis := types.NewGenesisState(types.DefaultParams(), []types.Validator{validator, jailed})
+	testGenesis.ValidatorStates = []types.GenesisValidatorState{types.NewGenesisValidatorState(jailed.GetOperator(), types.ValidatorStateJailed)}
+
+	// The jailed validator doesn't join Tendermint validator set
+	validatorUpdates := poa.InitGenesis(ctx, poaKeeper, testGenesis)
+	if len(validatorUpdates) != 1 {
+		t.Errorf("Should get exactly one validator update, got %v", len(validatorUpdates))
+	}
+
+	state, found := poaKeeper.GetValidatorState(ctx, jailed.GetOperator())
+	if !found || state != types.ValidatorStateJailed {
+		t.Errorf("The validator should be jailed, got %v", state)
+	}
+	if !poaKeeper.IsValidatorOutOfSet(ctx, jailed.GetOperator()) {
+		t.Errorf("The jailed validator should be out of the set")
+	}
+
+	// No update at the end of the block
+	if updates := poa.EndBlocker(ctx, poaKeeper); len(updates) != 0 {
+		t.Errorf("EndBlocker should not update a jailed validator from genesis, got %v", updates)
+	}
+
+	// The state is exported
+	exportedGenesis := poa.ExportGenesis(ctx, poaKeeper)
+	if !cmp.Equal(exportedGenesis.ValidatorStates, testGenesis.ValidatorStates) {
+		t.Errorf("Exported genesis validator states should be: %v, not %v", testGenesis.ValidatorStates, exportedGenesis.ValidatorStates)
+	}
+}
+
+func TestGenesisRewards(t *testing.T) {
+	ctx, poaKeeper, supplyKeeper := poa.MockContextWithSupply()
+	validator, _ := poa.MockValidator()
+	withdrawAddr := sdk.AccAddress(poa.MockValAddress())
+	rewards := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+
+	testGenesis := types.NewGenesisState(types.DefaultParams(), []types.Validator{validator})
+	testGenesis.Rewards = []types.GenesisValidatorRewards{types.NewGenesisValidatorRewards(validator.GetOperator(), rewards)}
+	testGenesis.WithdrawAddresses = []types.GenesisWithdrawAddress{types.NewGenesisWithdrawAddress(validator.GetOperator(), withdrawAddr)}
+
+	// The poa module account must hold the rewards
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("InitGenesis should panic if the poa module account doesn't hold the rewards")
+			}
+		}()
+		poa.InitGenesis(ctx.WithMultiStore(ctx.MultiStore().CacheMultiStore()), poaKeeper, testGenesis)
+	}()
+
+	if err := supplyKeeper.MintCoins(ctx, types.ModuleName, rewards); err != nil {
+		t.Fatalf("Cannot mint rewards: %v", err)
+	}
+	poa.InitGenesis(ctx, poaKeeper, testGenesis)
+
+	if !poaKeeper.GetValidatorRewards(ctx, validator.GetOperator()).IsEqual(rewards) {
+		t.Errorf("InitGenesis should set the rewards %v, got %v", rewards, poaKeeper.GetValidatorRewards(ctx, validator.GetOperator()))
+	}
+	if !poaKeeper.GetWithdrawAddress(ctx, validator.GetOperator()).Equals(withdrawAddr) {
+		t.Errorf("InitGenesis should set the withdraw address %v, got %v", withdrawAddr, poaKeeper.GetWithdrawAddress(ctx, validator.GetOperator()))
+	}
+
+	// The rewards and the withdraw addresses are exported
+	exportedGenesis := poa.ExportGenesis(ctx, poaKeeper)
+	if !cmp.Equal(exportedGenesis.Rewards, testGenesis.Rewards) {
+		t.Errorf("Exported genesis rewards should be: %v, not %v", testGenesis.Rewards, exportedGenesis.Rewards)
+	}
+	if !cmp.Equal(exportedGenesis.WithdrawAddresses, testGenesis.WithdrawAddresses) {
+		t.Errorf("Exported genesis withdraw addresses should be: %v, not %v", testGenesis.WithdrawAddresses, exportedGenesis.WithdrawAddresses)
+	}
+}
+
 func TestExportGenesis(t *testing.T) {
 	ctx, poaKeeper := poa.MockContext()
 	validator, _ := poa.MockValidator()
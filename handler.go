@@ -2,6 +2,7 @@ package poa
 
 import (
 	"fmt"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -18,10 +19,16 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgSubmitApplication(ctx, k, msg)
 		case types.MsgVote:
 			return handleMsgVote(ctx, k, msg)
+		case types.MsgVoteBatch:
+			return handleMsgVoteBatch(ctx, k, msg)
 		case types.MsgProposeKick:
 			return handleMsgProposeKick(ctx, k, msg)
 		case types.MsgLeaveValidatorSet:
 			return handleMsgLeaveValidatorSet(ctx, k, msg)
+		case types.MsgSetWithdrawAddress:
+			return handleMsgSetWithdrawAddress(ctx, k, msg)
+		case types.MsgWithdrawRewards:
+			return handleMsgWithdrawRewards(ctx, k, msg)
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
@@ -32,9 +39,9 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 // handleMsgSubmitApplication create a new application to become a validator
 func handleMsgSubmitApplication(ctx sdk.Context, k keeper.Keeper, msg types.MsgSubmitApplication) (*sdk.Result, error) {
 	// Check max validator is not reached
-	allValidators := k.GetAllValidators(ctx)
+	validatorCount := k.GetValidatorCount(ctx)
 	maxValidator := k.MaxValidators(ctx)
-	if uint16(len(allValidators)) == maxValidator {
+	if validatorCount >= uint64(maxValidator) {
 		return nil, types.ErrMaxValidatorsReached
 	}
 	// Candidate should not be a validator
@@ -51,6 +58,7 @@ func handleMsgSubmitApplication(ctx sdk.Context, k keeper.Keeper, msg types.MsgS
 	if k.Quorum(ctx) == 0 {
 		// The validator is directly appended in the validator set
 		k.AppendValidator(ctx, msg.Candidate)
+		k.AfterValidatorAppended(ctx, msg.Candidate.GetOperator())
 
 		ctx.EventManager().EmitEvent(
 			sdk.NewEvent(
@@ -74,6 +82,7 @@ func handleMsgSubmitApplication(ctx sdk.Context, k keeper.Keeper, msg types.MsgS
 
 		// Create the new application
 		k.AppendApplication(ctx, msg.Candidate)
+		k.AfterApplicationSubmitted(ctx, msg.Candidate.GetOperator())
 
 		ctx.EventManager().EmitEvent(
 			sdk.NewEvent(
@@ -105,13 +114,26 @@ func handleMsgProposeKick(ctx sdk.Context, k keeper.Keeper, msg types.MsgPropose
 	if !found {
 		return nil, types.ErrNotValidator
 	}
+
+	if err := proposeKick(ctx, k, candidate, msg.ProposerAddr, types.KickReasonProposed); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// proposeKick creates a kick proposal against a validator, or kicks it immediately if the quorum is 0
+// The proposer is a validator or the poa module for an automatic kick proposal
+func proposeKick(ctx sdk.Context, k keeper.Keeper, candidate types.Validator, proposerAddr sdk.ValAddress, reason uint16) error {
+	candidateAddr := candidate.GetOperator()
+
 	// Can't create a kick proposal if the candidate is leaving the validator set
-	valState, found := k.GetValidatorState(ctx, msg.CandidateAddr)
+	valState, found := k.GetValidatorState(ctx, candidateAddr)
 	if !found {
 		panic("A validator has no state")
 	}
 	if valState == types.ValidatorStateLeaving {
-		return nil, types.ErrValidatorLeaving
+		return types.ErrValidatorLeaving
 	}
 
 	// If quorum is 0 the candidate is immediatelly kicked from the validator set
@@ -123,32 +145,46 @@ func handleMsgProposeKick(ctx sdk.Context, k keeper.Keeper, msg types.MsgPropose
 			sdk.NewEvent(
 				types.EventTypeKickValidator,
 				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-				sdk.NewAttribute(types.AttributeKeyValidator, msg.CandidateAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyValidator, candidateAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyReason, types.KickReasonToString(reason)),
 			),
 		)
 	} else {
 		// If quorum is more than 0, we create a kick proposal vote
 
 		// Candidate should not be already in a kick proposal
-		_, found = k.GetKickProposal(ctx, msg.CandidateAddr)
+		_, found = k.GetKickProposal(ctx, candidateAddr)
 		if found {
-			return nil, types.ErrAlreadyInKickProposal
+			return types.ErrAlreadyInKickProposal
 		}
 
-		// Create the new application
+		// Create the new kick proposal
 		k.AppendKickProposal(ctx, candidate)
+		k.SetKickProposalReason(ctx, candidateAddr, reason)
+		k.AfterKickProposed(ctx, candidateAddr, proposerAddr)
 
 		ctx.EventManager().EmitEvent(
 			sdk.NewEvent(
 				types.EventTypeProposeKick,
 				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-				sdk.NewAttribute(types.AttributeKeyValidator, msg.CandidateAddr.String()),
-				sdk.NewAttribute(types.AttributeKeyProposer, msg.ProposerAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyValidator, candidateAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyProposer, proposerAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyReason, types.KickReasonToString(reason)),
 			),
 		)
 	}
 
-	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+	return nil
+}
+
+// tallyAttributes returns the attributes of a vote event describing the progress of the vote
+func tallyAttributes(vote types.Vote, voterPoolSize uint64, quorum uint64) []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyApprovals, strconv.FormatUint(vote.GetApprovals(), 10)),
+		sdk.NewAttribute(types.AttributeKeyTotal, strconv.FormatUint(vote.GetTotal(), 10)),
+		sdk.NewAttribute(types.AttributeKeyRequiredApprovals, strconv.FormatUint(types.NecessaryApprovals(voterPoolSize, quorum), 10)),
+		sdk.NewAttribute(types.AttributeKeyPoolSize, strconv.FormatUint(voterPoolSize, 10)),
+	}
 }
 
 // handleMsgVote handles a vote performed by a validator
@@ -163,12 +199,28 @@ func handleMsgVote(ctx sdk.Context, k keeper.Keeper, msg types.MsgVote) (*sdk.Re
 	}
 }
 
+// handleMsgVoteBatch handles several votes performed by a validator
+// The state is only updated if all the votes succeed
+func handleMsgVoteBatch(ctx sdk.Context, k keeper.Keeper, msg types.MsgVoteBatch) (*sdk.Result, error) {
+	cacheCtx, write := ctx.CacheContext()
+
+	for i, msgVote := range msg.MsgVotes() {
+		if _, err := handleMsgVote(cacheCtx, k, msgVote); err != nil {
+			return nil, sdkerrors.Wrapf(err, "vote %d", i)
+		}
+	}
+	// The events of the votes are only emitted if all the votes succeed
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func handleMsgVoteApplication(ctx sdk.Context, k keeper.Keeper, msg types.MsgVote) (*sdk.Result, error) {
 	// Check max validator is not reached. If max validator is reached, not application can be voted
-	allValidators := k.GetAllValidators(ctx)
-	validatorCount := len(allValidators)
+	validatorCount := k.GetValidatorCount(ctx)
 	maxValidator := k.MaxValidators(ctx)
-	if uint16(validatorCount) == maxValidator {
+	if validatorCount >= uint64(maxValidator) {
 		return nil, types.ErrMaxValidatorsReached
 	}
 
@@ -185,10 +237,13 @@ func handleMsgVoteApplication(ctx sdk.Context, k keeper.Keeper, msg types.MsgVot
 	}
 
 	// Check if already voted and vote
-	alreadyVoted := application.AddVote(msg.VoterAddr, msg.Approve)
+	alreadyVoted := k.AddVote(ctx, msg.VoteType, &application, msg.VoterAddr, msg.Approve)
 	if alreadyVoted {
 		return nil, types.ErrAlreadyVoted
 	}
+	k.RecordVote(ctx, msg.VoterAddr, msg.VoteType)
+	voterPoolSize := validatorCount
+	quorum := uint64(k.Quorum(ctx))
 
 	// Emit the vote event
 	if msg.Approve {
@@ -198,7 +253,7 @@ func handleMsgVoteApplication(ctx sdk.Context, k keeper.Keeper, msg types.MsgVot
 				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
 				sdk.NewAttribute(types.AttributeKeyVoter, msg.VoterAddr.String()),
 				sdk.NewAttribute(types.AttributeKeyCandidate, msg.CandidateAddr.String()),
-			),
+			).AppendAttributes(tallyAttributes(application, voterPoolSize, quorum)...),
 		)
 	} else {
 		ctx.EventManager().EmitEvent(
@@ -207,12 +262,12 @@ func handleMsgVoteApplication(ctx sdk.Context, k keeper.Keeper, msg types.MsgVot
 				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
 				sdk.NewAttribute(types.AttributeKeyVoter, msg.VoterAddr.String()),
 				sdk.NewAttribute(types.AttributeKeyCandidate, msg.CandidateAddr.String()),
-			),
+			).AppendAttributes(tallyAttributes(application, voterPoolSize, quorum)...),
 		)
 	}
 
 	// Check if the quorum has been reached
-	reached, approved, err := application.CheckQuorum(uint64(validatorCount), uint64(k.Quorum(ctx)))
+	reached, approved, err := application.CheckQuorum(voterPoolSize, quorum)
 	if err != nil {
 		return nil, err
 	}
@@ -220,8 +275,9 @@ func handleMsgVoteApplication(ctx sdk.Context, k keeper.Keeper, msg types.MsgVot
 	if reached {
 		if approved {
 			// Candidate is appended to the validator set
-			k.RemoveApplication(ctx, msg.CandidateAddr)
+			k.CloseApplication(ctx, application, types.ProposalOutcomeApproved)
 			k.AppendValidator(ctx, application.GetSubject())
+			k.AfterValidatorAppended(ctx, msg.CandidateAddr)
 
 			// Emit approved event
 			ctx.EventManager().EmitEvent(
@@ -233,7 +289,7 @@ func handleMsgVoteApplication(ctx sdk.Context, k keeper.Keeper, msg types.MsgVot
 			)
 		} else {
 			// Candidate is rejected from joining the validator set
-			k.RemoveApplication(ctx, msg.CandidateAddr)
+			k.CloseApplication(ctx, application, types.ProposalOutcomeRejected)
 
 			// Emit rejected event
 			ctx.EventManager().EmitEvent(
@@ -271,10 +327,16 @@ func handleMsgVoteTypeKickProposal(ctx sdk.Context, k keeper.Keeper, msg types.M
 	}
 
 	// Check if already voted and vote
-	alreadyVoted := kickProposal.AddVote(msg.VoterAddr, msg.Approve)
+	alreadyVoted := k.AddVote(ctx, msg.VoteType, &kickProposal, msg.VoterAddr, msg.Approve)
 	if alreadyVoted {
 		return nil, types.ErrAlreadyVoted
 	}
+	k.RecordVote(ctx, msg.VoterAddr, msg.VoteType)
+
+	// Get validator count
+	// We decrement validator count, the candidate of the kick proposal cannot vote
+	voterPoolSize := k.GetValidatorCount(ctx) - 1
+	quorum := uint64(k.Quorum(ctx))
 
 	// Emit the vote event
 	if msg.Approve {
@@ -284,7 +346,7 @@ func handleMsgVoteTypeKickProposal(ctx sdk.Context, k keeper.Keeper, msg types.M
 				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
 				sdk.NewAttribute(types.AttributeKeyVoter, msg.VoterAddr.String()),
 				sdk.NewAttribute(types.AttributeKeyValidator, msg.CandidateAddr.String()),
-			),
+			).AppendAttributes(tallyAttributes(kickProposal, voterPoolSize, quorum)...),
 		)
 	} else {
 		ctx.EventManager().EmitEvent(
@@ -293,17 +355,12 @@ func handleMsgVoteTypeKickProposal(ctx sdk.Context, k keeper.Keeper, msg types.M
 				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
 				sdk.NewAttribute(types.AttributeKeyVoter, msg.VoterAddr.String()),
 				sdk.NewAttribute(types.AttributeKeyValidator, msg.CandidateAddr.String()),
-			),
+			).AppendAttributes(tallyAttributes(kickProposal, voterPoolSize, quorum)...),
 		)
 	}
 
-	// Get validator count
-	allValidators := k.GetAllValidators(ctx)
-	validatorCount := len(allValidators)
-
 	// Check if the quorum has been reached
-	// We decrement validator count, the candidate of the kick proposal cannot vote
-	reached, approved, err := kickProposal.CheckQuorum(uint64(validatorCount)-1, uint64(k.Quorum(ctx)))
+	reached, approved, err := kickProposal.CheckQuorum(voterPoolSize, quorum)
 	if err != nil {
 		return nil, err
 	}
@@ -312,7 +369,7 @@ func handleMsgVoteTypeKickProposal(ctx sdk.Context, k keeper.Keeper, msg types.M
 		if approved {
 			// The validator leave the validator set
 			// The state is set to leave, End Blocker will remove definitely the validator
-			k.RemoveKickProposal(ctx, msg.CandidateAddr)
+			k.CloseKickProposal(ctx, kickProposal, types.ProposalOutcomeApproved)
 			k.SetValidatorState(ctx, kickProposal.GetSubject(), types.ValidatorStateLeaving)
 
 			// Emit approved event
@@ -325,7 +382,7 @@ func handleMsgVoteTypeKickProposal(ctx sdk.Context, k keeper.Keeper, msg types.M
 			)
 		} else {
 			// Kick proposal rejected, validator is not removed
-			k.RemoveKickProposal(ctx, msg.CandidateAddr)
+			k.CloseKickProposal(ctx, kickProposal, types.ProposalOutcomeRejected)
 
 			// Emit rejected event
 			ctx.EventManager().EmitEvent(
@@ -351,17 +408,15 @@ func handleMsgLeaveValidatorSet(ctx sdk.Context, k keeper.Keeper, msg types.MsgL
 		return nil, types.ErrNotValidator
 	}
 
-	// Get validator count
-	allValidators := k.GetAllValidators(ctx)
-	validatorCount := len(allValidators)
-	if validatorCount == 1 {
+	// The last bonded validator can't leave
+	if k.IsLastBondedValidator(ctx, msg.ValidatorAddr) {
 		return nil, types.ErrOnlyOneValidator
 	}
 
-	// If a kick proposal exist for this validator, remove it
-	_, found = k.GetKickProposal(ctx, msg.ValidatorAddr)
+	// If a kick proposal exist for this validator, it is withdrawn
+	kickProposal, found := k.GetKickProposal(ctx, msg.ValidatorAddr)
 	if found {
-		k.RemoveKickProposal(ctx, msg.ValidatorAddr)
+		k.CloseKickProposal(ctx, kickProposal, types.ProposalOutcomeWithdrawn)
 	}
 
 	// Set the state of the validator to leaving, End Blocker will remove the validator from the keeper
@@ -378,3 +433,54 @@ func handleMsgLeaveValidatorSet(ctx sdk.Context, k keeper.Keeper, msg types.MsgL
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+// handleMsgSetWithdrawAddress sets the address receiving the rewards of a validator
+func handleMsgSetWithdrawAddress(ctx sdk.Context, k keeper.Keeper, msg types.MsgSetWithdrawAddress) (*sdk.Result, error) {
+	// Sender must be a validator
+	_, found := k.GetValidator(ctx, msg.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNotValidator
+	}
+
+	k.SetWithdrawAddress(ctx, msg.ValidatorAddr, msg.WithdrawAddr)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSetWithdrawAddress,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(types.AttributeKeyValidator, msg.ValidatorAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyWithdrawAddress, msg.WithdrawAddr.String()),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// handleMsgWithdrawRewards sends the rewards of a validator to its withdraw address
+func handleMsgWithdrawRewards(ctx sdk.Context, k keeper.Keeper, msg types.MsgWithdrawRewards) (*sdk.Result, error) {
+	// Sender must be a validator
+	_, found := k.GetValidator(ctx, msg.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNotValidator
+	}
+
+	if k.GetValidatorRewards(ctx, msg.ValidatorAddr).IsZero() {
+		return nil, types.ErrNoRewards
+	}
+
+	rewards, err := k.WithdrawRewards(ctx, msg.ValidatorAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeWithdrawRewards,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(types.AttributeKeyValidator, msg.ValidatorAddr.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, rewards.String()),
+		),
+	)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
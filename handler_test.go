@@ -3,6 +3,8 @@ package poa_test
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/ltacker/poa"
 	"github.com/ltacker/poa/types"
 )
@@ -77,6 +79,40 @@ func TestHandleMsgSubmitApplication(t *testing.T) {
 	}
 }
 
+func TestMaxValidatorsLowered(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewHandler(poaKeeper)
+	voter, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	validator3, _ := poa.MockValidator()
+	candidate1, _ := poa.MockValidator()
+	candidate2, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.NewParams(15, 66))
+	poaKeeper.AppendValidator(ctx, voter)
+	poaKeeper.AppendValidator(ctx, validator2)
+	poaKeeper.AppendValidator(ctx, validator3)
+	poaKeeper.AppendApplication(ctx, candidate1)
+
+	// The max validators is lowered below the number of validators
+	poaKeeper.SetParams(ctx, types.NewParams(2, 66))
+
+	// No application can be submitted
+	_, err := handler(ctx, types.NewMsgSubmitApplication(candidate2))
+	if err == nil || err.Error() != types.ErrMaxValidatorsReached.Error() {
+		t.Errorf("MsgSubmitApplication above max validators, error should be %v, got %v", types.ErrMaxValidatorsReached, err)
+	}
+
+	// No application can be voted
+	_, err = handler(ctx, types.NewMsgVote(types.VoteTypeApplication, voter.GetOperator(), candidate1.GetOperator(), true))
+	if err == nil || err.Error() != types.ErrMaxValidatorsReached.Error() {
+		t.Errorf("MsgVote on an application above max validators, error should be %v, got %v", types.ErrMaxValidatorsReached, err)
+	}
+	application, _ := poaKeeper.GetApplication(ctx, candidate1.GetOperator())
+	if application.GetTotal() != 0 {
+		t.Errorf("MsgVote on an application above max validators should not count the vote")
+	}
+}
+
 func TestHandleMsgProposeKick(t *testing.T) {
 	// Test with maxValidator=15, quorum=66
 	ctx, poaKeeper := poa.MockContext()
@@ -434,3 +470,330 @@ func testHandleMsgLeaveValidatorSet(t *testing.T) {
 		t.Errorf("MsgLeaveValidatorSet should set the state of the validator to leaving")
 	}
 }
+
+func TestHandleMsgLeaveValidatorSetJailed(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewHandler(poaKeeper)
+	joined, _ := poa.MockValidator()
+	jailed, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+
+	poaKeeper.AppendValidator(ctx, joined)
+	poaKeeper.SetValidatorState(ctx, joined, types.ValidatorStateJoined)
+	poaKeeper.AppendValidator(ctx, jailed)
+	poaKeeper.SetValidatorState(ctx, jailed, types.ValidatorStateJailed)
+	poaKeeper.SetValidatorOutOfSet(ctx, jailed.GetOperator())
+
+	// The joined validator is the last validator in the Tendermint validator set
+	_, err := handler(ctx, types.NewMsgLeaveValidatorSet(joined.GetOperator()))
+	if err == nil || err.Error() != types.ErrOnlyOneValidator.Error() {
+		t.Errorf("MsgLeaveValidatorSet with one bonded validator, error should be %v, got %v", types.ErrOnlyOneValidator, err)
+	}
+
+	// The jailed validator can leave
+	_, err = handler(ctx, types.NewMsgLeaveValidatorSet(jailed.GetOperator()))
+	if err != nil {
+		t.Errorf("MsgLeaveValidatorSet should let a jailed validator leave, got error %v", err)
+	}
+	if updates := poa.EndBlocker(ctx, poaKeeper); len(updates) != 0 {
+		t.Errorf("EndBlocker should not update the Tendermint validator set, got %v", updates)
+	}
+	if _, found := poaKeeper.GetValidator(ctx, joined.GetOperator()); !found {
+		t.Errorf("The joined validator should remain in the validator set")
+	}
+}
+
+func TestHandleMsgSetWithdrawAddress(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewHandler(poaKeeper)
+	validator, _ := poa.MockValidator()
+	notValidator := poa.MockValAddress()
+	withdrawAddr := sdk.AccAddress(poa.MockValAddress())
+	poaKeeper.AppendValidator(ctx, validator)
+
+	// The withdraw address is set
+	msg := types.NewMsgSetWithdrawAddress(validator.GetOperator(), withdrawAddr)
+	_, err := handler(ctx, msg)
+	if err != nil {
+		t.Errorf("MsgSetWithdrawAddress should set the withdraw address, got error %v", err)
+	}
+	if !poaKeeper.GetWithdrawAddress(ctx, validator.GetOperator()).Equals(withdrawAddr) {
+		t.Errorf("MsgSetWithdrawAddress should set the withdraw address to %v, got %v", withdrawAddr, poaKeeper.GetWithdrawAddress(ctx, validator.GetOperator()))
+	}
+
+	// The sender must be a validator
+	msg = types.NewMsgSetWithdrawAddress(notValidator, withdrawAddr)
+	_, err = handler(ctx, msg)
+	if err.Error() != types.ErrNotValidator.Error() {
+		t.Errorf("MsgSetWithdrawAddress with no validator, error should be %v, got %v", types.ErrNotValidator.Error(), err.Error())
+	}
+}
+
+func TestHandleMsgWithdrawRewards(t *testing.T) {
+	ctx, poaKeeper, _ := poa.MockContextWithSupply()
+	handler := poa.NewHandler(poaKeeper)
+	validator, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.DefaultParams().WithRewards(sdk.NewCoins(sdk.NewInt64Coin("stake", 10)), sdk.ZeroDec()))
+	poaKeeper.AppendValidator(ctx, validator)
+	poaKeeper.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+
+	// No rewards to withdraw
+	msg := types.NewMsgWithdrawRewards(validator.GetOperator())
+	_, err := handler(ctx, msg)
+	if err.Error() != types.ErrNoRewards.Error() {
+		t.Errorf("MsgWithdrawRewards with no rewards, error should be %v, got %v", types.ErrNoRewards.Error(), err.Error())
+	}
+
+	// The rewards are withdrawn
+	poaKeeper.AllocateRewards(ctx, validator.GetConsAddr())
+	_, err = handler(ctx, msg)
+	if err != nil {
+		t.Errorf("MsgWithdrawRewards should withdraw the rewards, got error %v", err)
+	}
+	if !poaKeeper.GetValidatorRewards(ctx, validator.GetOperator()).IsZero() {
+		t.Errorf("MsgWithdrawRewards should withdraw all the rewards, %v remaining", poaKeeper.GetValidatorRewards(ctx, validator.GetOperator()))
+	}
+}
+
+func TestProposalHistory(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewHandler(poaKeeper)
+	voter1, _ := poa.MockValidator()
+	voter2, _ := poa.MockValidator()
+	candidate, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.NewParams(15, 100)) // Set quorum to 100%
+
+	poaKeeper.AppendValidator(ctx, voter1)
+	poaKeeper.AppendValidator(ctx, voter2)
+	poaKeeper.AppendApplication(ctx, candidate)
+	ctx = ctx.WithBlockHeight(10)
+
+	// The approved application is archived with the choices of the voters
+	handler(ctx, types.NewMsgVote(types.VoteTypeApplication, voter1.GetOperator(), candidate.GetOperator(), true))
+	handler(ctx, types.NewMsgVote(types.VoteTypeApplication, voter2.GetOperator(), candidate.GetOperator(), true))
+	closedProposal, found := poaKeeper.GetClosedProposal(ctx, 0)
+	if !found {
+		t.Fatalf("An approved application should be archived")
+	}
+	if closedProposal.ProposalType != types.VoteTypeApplication || closedProposal.Outcome != types.ProposalOutcomeApproved {
+		t.Errorf("The archived application should be approved, got type %v outcome %v", closedProposal.ProposalType, closedProposal.Outcome)
+	}
+	if closedProposal.ClosingHeight != 10 {
+		t.Errorf("The archived application should be closed at height 10, got %v", closedProposal.ClosingHeight)
+	}
+	if closedProposal.Approvals != 2 || len(closedProposal.Votes) != 2 || !closedProposal.Votes[1].Approve {
+		t.Errorf("The archived application should contain the final tally, got %v", closedProposal)
+	}
+
+	// The kick proposal of a validator leaving by itself is withdrawn
+	poaKeeper.SetValidatorState(ctx, voter2, types.ValidatorStateJoined)
+	poaKeeper.AppendKickProposal(ctx, voter2)
+	_, err := handler(ctx, types.NewMsgLeaveValidatorSet(voter2.GetOperator()))
+	if err != nil {
+		t.Fatalf("MsgLeaveValidatorSet should be handled, got error %v", err)
+	}
+	closedProposal, found = poaKeeper.GetClosedProposal(ctx, 1)
+	if !found {
+		t.Fatalf("A withdrawn kick proposal should be archived")
+	}
+	if closedProposal.ProposalType != types.VoteTypeKickProposal || closedProposal.Outcome != types.ProposalOutcomeWithdrawn {
+		t.Errorf("The archived kick proposal should be withdrawn, got type %v outcome %v", closedProposal.ProposalType, closedProposal.Outcome)
+	}
+	if len(poaKeeper.GetAllClosedProposals(ctx)) != 2 {
+		t.Errorf("The history should contain 2 closed proposals, got %v", len(poaKeeper.GetAllClosedProposals(ctx)))
+	}
+}
+
+func TestParticipation(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewHandler(poaKeeper)
+	voter1, _ := poa.MockValidator()
+	voter2, _ := poa.MockValidator()
+	voter3, _ := poa.MockValidator()
+	candidate, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+
+	poaKeeper.AppendValidator(ctx, voter1)
+	poaKeeper.AppendValidator(ctx, voter2)
+	poaKeeper.AppendValidator(ctx, voter3)
+	poaKeeper.AppendApplication(ctx.WithBlockHeight(5), candidate)
+	poaKeeper.AppendKickProposal(ctx.WithBlockHeight(5), voter3)
+	ctx = ctx.WithBlockHeight(10)
+
+	// The application is approved by 2 of the 3 validators, the quorum is reached before voter 3 votes
+	handler(ctx, types.NewMsgVote(types.VoteTypeApplication, voter1.GetOperator(), candidate.GetOperator(), true))
+	handler(ctx, types.NewMsgVote(types.VoteTypeApplication, voter2.GetOperator(), candidate.GetOperator(), true))
+	participation := poaKeeper.GetParticipation(ctx, voter1.GetOperator())
+	if participation.ApplicationVotes != 1 || participation.MissedVotes != 0 || participation.LastVoteHeight != 10 {
+		t.Errorf("The participation of voter 1 should have 1 application vote at height 10, got %v", participation)
+	}
+	participation = poaKeeper.GetParticipation(ctx, voter3.GetOperator())
+	if participation.ApplicationVotes != 0 || participation.MissedVotes != 0 {
+		t.Errorf("Voter 3 should not miss a proposal decided by the quorum, got %v", participation)
+	}
+
+	// The kick proposal is rejected by the quorum, the new validator joined during the vote
+	handler(ctx, types.NewMsgVote(types.VoteTypeKickProposal, voter1.GetOperator(), voter3.GetOperator(), false))
+	handler(ctx, types.NewMsgVote(types.VoteTypeKickProposal, voter2.GetOperator(), voter3.GetOperator(), false))
+	participation = poaKeeper.GetParticipation(ctx, voter2.GetOperator())
+	if participation.ApplicationVotes != 1 || participation.KickProposalVotes != 1 {
+		t.Errorf("The participation of voter 2 should have 1 application vote and 1 kick proposal vote, got %v", participation)
+	}
+	participation = poaKeeper.GetParticipation(ctx, candidate.GetOperator())
+	if participation.MissedVotes != 0 {
+		t.Errorf("The new validator should not miss a proposal decided by the quorum, got %v missed votes", participation.MissedVotes)
+	}
+
+	// A kick proposal expires without the vote of voter 3 and the new validator
+	poaKeeper.AppendKickProposal(ctx, voter2)
+	handler(ctx, types.NewMsgVote(types.VoteTypeKickProposal, voter1.GetOperator(), voter2.GetOperator(), true))
+	kickProposal, _ := poaKeeper.GetKickProposal(ctx, voter2.GetOperator())
+	poaKeeper.CloseKickProposal(ctx.WithBlockHeight(20), kickProposal, types.ProposalOutcomeExpired)
+	participation = poaKeeper.GetParticipation(ctx, voter3.GetOperator())
+	if participation.MissedVotes != 1 || participation.ConsecutiveMissedVotes != 1 {
+		t.Errorf("Voter 3 should miss the expired kick proposal, got %v", participation)
+	}
+	for _, validator := range []types.Validator{voter1, voter2, candidate} {
+		participation = poaKeeper.GetParticipation(ctx, validator.GetOperator())
+		if participation.MissedVotes != 0 {
+			t.Errorf("The voter, the subject and the validator joined during the vote should not miss the vote, got %v for %v", participation.MissedVotes, validator.GetOperator())
+		}
+	}
+}
+
+func TestVoteEvents(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewHandler(poaKeeper)
+	voter1, _ := poa.MockValidator()
+	voter2, _ := poa.MockValidator()
+	voter3, _ := poa.MockValidator()
+	candidate, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.NewParams(15, 100))
+
+	poaKeeper.AppendValidator(ctx, voter1)
+	poaKeeper.AppendValidator(ctx, voter2)
+	poaKeeper.AppendValidator(ctx, voter3)
+	poaKeeper.AppendApplication(ctx, candidate)
+	poaKeeper.AppendKickProposal(ctx, voter3)
+
+	// Get the attributes of the last event of a type
+	eventAttributes := func(res *sdk.Result, eventType string) map[string]string {
+		attributes := make(map[string]string)
+		for _, event := range res.Events {
+			if event.Type != eventType {
+				continue
+			}
+			for _, attribute := range event.Attributes {
+				attributes[string(attribute.Key)] = string(attribute.Value)
+			}
+		}
+		return attributes
+	}
+
+	// The application vote reports the tally among the 3 validators
+	res, err := handler(ctx, types.NewMsgVote(types.VoteTypeApplication, voter1.GetOperator(), candidate.GetOperator(), true))
+	if err != nil {
+		t.Fatalf("MsgVote should vote on an application, got error %v", err)
+	}
+	attributes := eventAttributes(res, types.EventTypeApproveApplication)
+	if attributes[types.AttributeKeyApprovals] != "1" || attributes[types.AttributeKeyTotal] != "1" {
+		t.Errorf("The approve application event should have 1 approval for 1 vote, got %v", attributes)
+	}
+	if attributes[types.AttributeKeyRequiredApprovals] != "3" || attributes[types.AttributeKeyPoolSize] != "3" {
+		t.Errorf("The approve application event should require 3 approvals from 3 voters, got %v", attributes)
+	}
+
+	// The subject of the kick proposal is not in the voter pool
+	res, err = handler(ctx, types.NewMsgVote(types.VoteTypeKickProposal, voter1.GetOperator(), voter3.GetOperator(), true))
+	if err != nil {
+		t.Fatalf("MsgVote should vote on a kick proposal, got error %v", err)
+	}
+	attributes = eventAttributes(res, types.EventTypeApproveKickProposal)
+	if attributes[types.AttributeKeyApprovals] != "1" || attributes[types.AttributeKeyTotal] != "1" {
+		t.Errorf("The approve kick proposal event should have 1 approval for 1 vote, got %v", attributes)
+	}
+	if attributes[types.AttributeKeyRequiredApprovals] != "2" || attributes[types.AttributeKeyPoolSize] != "2" {
+		t.Errorf("The approve kick proposal event should require 2 approvals from 2 voters, got %v", attributes)
+	}
+}
+
+func TestHandleMsgVoteBatch(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewHandler(poaKeeper)
+	voter1, _ := poa.MockValidator()
+	voter2, _ := poa.MockValidator()
+	voter3, _ := poa.MockValidator()
+	candidate1, _ := poa.MockValidator()
+	candidate2, _ := poa.MockValidator()
+	nothing := poa.MockValAddress()
+	poaKeeper.SetParams(ctx, types.NewParams(15, 100))
+
+	poaKeeper.AppendValidator(ctx, voter1)
+	poaKeeper.AppendValidator(ctx, voter2)
+	poaKeeper.AppendValidator(ctx, voter3)
+	poaKeeper.AppendApplication(ctx, candidate1)
+	poaKeeper.AppendApplication(ctx, candidate2)
+	poaKeeper.AppendKickProposal(ctx, voter3)
+
+	// A batch cannot be empty or vote twice on a proposal
+	msg := types.NewMsgVoteBatch(voter1.GetOperator(), nil)
+	if msg.ValidateBasic() == nil {
+		t.Errorf("MsgVoteBatch without vote should be invalid")
+	}
+	msg = types.NewMsgVoteBatch(voter1.GetOperator(), []types.BatchVote{
+		types.NewBatchVote(types.VoteTypeApplication, candidate1.GetOperator(), true),
+		types.NewBatchVote(types.VoteTypeApplication, candidate1.GetOperator(), false),
+	})
+	if msg.ValidateBasic() == nil {
+		t.Errorf("MsgVoteBatch voting twice on a proposal should be invalid")
+	}
+
+	// A batch with an invalid vote fails without performing the other votes
+	msg = types.NewMsgVoteBatch(voter1.GetOperator(), []types.BatchVote{
+		types.NewBatchVote(types.VoteTypeApplication, candidate1.GetOperator(), true),
+		types.NewBatchVote(types.VoteTypeApplication, nothing, true),
+	})
+	_, err := handler(ctx, msg)
+	if !types.ErrNoApplicationFound.Is(err) {
+		t.Errorf("MsgVoteBatch should fail with %v, got %v", types.ErrNoApplicationFound, err)
+	}
+	application, _ := poaKeeper.GetApplication(ctx, candidate1.GetOperator())
+	if application.GetTotal() != 0 {
+		t.Errorf("A failed MsgVoteBatch should not perform any vote, got %v votes", application.GetTotal())
+	}
+
+	// The votes of a valid batch are all performed with their events
+	msg = types.NewMsgVoteBatch(voter1.GetOperator(), []types.BatchVote{
+		types.NewBatchVote(types.VoteTypeApplication, candidate1.GetOperator(), true),
+		types.NewBatchVote(types.VoteTypeApplication, candidate2.GetOperator(), true),
+		types.NewBatchVote(types.VoteTypeKickProposal, voter3.GetOperator(), false),
+	})
+	res, err := handler(ctx, msg)
+	if err != nil {
+		t.Fatalf("MsgVoteBatch should perform the votes, got error %v", err)
+	}
+	application, _ = poaKeeper.GetApplication(ctx, candidate1.GetOperator())
+	if application.GetTotal() != 1 || application.GetApprovals() != 1 {
+		t.Errorf("MsgVoteBatch should approve the application of candidate 1")
+	}
+	application, _ = poaKeeper.GetApplication(ctx, candidate2.GetOperator())
+	if application.GetTotal() != 1 || application.GetApprovals() != 1 {
+		t.Errorf("MsgVoteBatch should approve the application of candidate 2")
+	}
+
+	// Quorum 100%: the reject closes the kick proposal
+	_, found := poaKeeper.GetKickProposal(ctx, voter3.GetOperator())
+	if found {
+		t.Errorf("MsgVoteBatch should reject the kick proposal")
+	}
+
+	voteEvents := 0
+	for _, event := range res.Events {
+		if event.Type == types.EventTypeApproveApplication || event.Type == types.EventTypeRejectKickProposal {
+			voteEvents++
+		}
+	}
+	if voteEvents != 3 {
+		t.Errorf("MsgVoteBatch should emit an event for each vote, got %v", voteEvents)
+	}
+}
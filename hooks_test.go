@@ -0,0 +1,131 @@
+package poa_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/types"
+)
+
+// Hooks recording the calls for test
+type recordingHooks struct {
+	calls []string
+}
+
+func (h *recordingHooks) AfterApplicationSubmitted(_ sdk.Context, candidateAddr sdk.ValAddress) {
+	h.calls = append(h.calls, "AfterApplicationSubmitted:"+candidateAddr.String())
+}
+func (h *recordingHooks) AfterValidatorAppended(_ sdk.Context, valAddr sdk.ValAddress) {
+	h.calls = append(h.calls, "AfterValidatorAppended:"+valAddr.String())
+}
+func (h *recordingHooks) AfterValidatorJoined(_ sdk.Context, _ sdk.ConsAddress, valAddr sdk.ValAddress) {
+	h.calls = append(h.calls, "AfterValidatorJoined:"+valAddr.String())
+}
+func (h *recordingHooks) AfterKickProposed(_ sdk.Context, candidateAddr sdk.ValAddress, _ sdk.ValAddress) {
+	h.calls = append(h.calls, "AfterKickProposed:"+candidateAddr.String())
+}
+func (h *recordingHooks) BeforeValidatorRemoved(_ sdk.Context, _ sdk.ConsAddress, valAddr sdk.ValAddress) {
+	h.calls = append(h.calls, "BeforeValidatorRemoved:"+valAddr.String())
+}
+
+func (h *recordingHooks) AfterValidatorRemoved(_ sdk.Context, _ sdk.ConsAddress, valAddr sdk.ValAddress) {
+	h.calls = append(h.calls, "AfterValidatorRemoved:"+valAddr.String())
+}
+
+func (h *recordingHooks) called(call string) bool {
+	for _, c := range h.calls {
+		if c == call {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPoaHooks(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	hooks1 := &recordingHooks{}
+	hooks2 := &recordingHooks{}
+	poaKeeper.SetHooks(types.NewMultiPoaHooks(hooks1, hooks2))
+	handler := poa.NewHandler(poaKeeper)
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	candidate, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.NewParams(15, 50))
+
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.AppendValidator(ctx, validator2)
+
+	// Submitting an application calls AfterApplicationSubmitted
+	_, err := handler(ctx, types.NewMsgSubmitApplication(candidate))
+	if err != nil {
+		t.Fatalf("MsgSubmitApplication should submit an application, got error %v", err)
+	}
+	if !hooks1.called("AfterApplicationSubmitted:" + candidate.GetOperator().String()) {
+		t.Errorf("MsgSubmitApplication should call AfterApplicationSubmitted")
+	}
+
+	// Approving the application calls AfterValidatorAppended
+	_, err = handler(ctx, types.NewMsgVote(types.VoteTypeApplication, validator1.GetOperator(), candidate.GetOperator(), true))
+	if err != nil {
+		t.Fatalf("MsgVote should vote on the application, got error %v", err)
+	}
+	if !hooks1.called("AfterValidatorAppended:" + candidate.GetOperator().String()) {
+		t.Errorf("MsgVote approving the application should call AfterValidatorAppended")
+	}
+
+	// Proposing a kick calls AfterKickProposed
+	_, err = handler(ctx, types.NewMsgProposeKick(validator2.GetOperator(), validator1.GetOperator()))
+	if err != nil {
+		t.Fatalf("MsgProposeKick should create a kick proposal, got error %v", err)
+	}
+	if !hooks1.called("AfterKickProposed:" + validator2.GetOperator().String()) {
+		t.Errorf("MsgProposeKick should call AfterKickProposed")
+	}
+
+	// EndBlocker calls AfterValidatorJoined for joining validators and BeforeValidatorRemoved for leaving ones
+	_, err = handler(ctx, types.NewMsgLeaveValidatorSet(validator2.GetOperator()))
+	if err != nil {
+		t.Fatalf("MsgLeaveValidatorSet should leave the validator set, got error %v", err)
+	}
+	poa.EndBlocker(ctx, poaKeeper)
+	if !hooks1.called("AfterValidatorJoined:" + candidate.GetOperator().String()) {
+		t.Errorf("EndBlocker should call AfterValidatorJoined for a joining validator")
+	}
+	if !hooks1.called("BeforeValidatorRemoved:" + validator2.GetOperator().String()) {
+		t.Errorf("EndBlocker should call BeforeValidatorRemoved for a leaving validator")
+	}
+
+	// AfterValidatorRemoved is called once the removed validator is no longer in the last commit
+	afterRemoved := "AfterValidatorRemoved:" + validator2.GetOperator().String()
+	if hooks1.called(afterRemoved) {
+		t.Errorf("EndBlocker should not call AfterValidatorRemoved in the block of the removal")
+	}
+	poa.EndBlocker(ctx.WithBlockHeight(ctx.BlockHeight()+1), poaKeeper)
+	if hooks1.called(afterRemoved) {
+		t.Errorf("EndBlocker should not call AfterValidatorRemoved while the validator is in the last commit")
+	}
+	poa.EndBlocker(ctx.WithBlockHeight(ctx.BlockHeight()+2), poaKeeper)
+	if !hooks1.called(afterRemoved) {
+		t.Errorf("EndBlocker should call AfterValidatorRemoved once the validator left the last commit")
+	}
+
+	// All the hooks are called
+	if len(hooks1.calls) != len(hooks2.calls) {
+		t.Errorf("All the hooks should be called, got %v and %v calls", len(hooks1.calls), len(hooks2.calls))
+	}
+}
+
+func TestSetHooksTwice(t *testing.T) {
+	_, poaKeeper := poa.MockContext()
+	poaKeeper.SetHooks(&recordingHooks{})
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SetHooks should panic if the hooks are set twice")
+		}
+	}()
+
+	poaKeeper.SetHooks(&recordingHooks{})
+}
@@ -50,6 +50,7 @@ func (k Keeper) AppendApplication(ctx sdk.Context, candidate types.Validator) {
 	applicationNewVote := types.NewVote(candidate)
 	k.SetApplication(ctx, applicationNewVote)
 	k.SetApplicationByConsAddr(ctx, applicationNewVote)
+	k.setProposalOpenHeight(ctx, types.VoteTypeApplication, candidate.GetOperator())
 }
 
 // Remove the application
@@ -65,6 +66,8 @@ func (k Keeper) RemoveApplication(ctx sdk.Context, address sdk.ValAddress) {
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(types.GetApplicationKey(address))
 	store.Delete(types.GetApplicationByConsAddrKey(consAddr))
+	store.Delete(types.GetProposalOpenHeightKey(types.VoteTypeApplication, address))
+	k.removeVoterChoices(ctx, types.VoteTypeApplication, address)
 }
 
 // Get the set of all application
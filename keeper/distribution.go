@@ -0,0 +1,180 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ltacker/poa/types"
+)
+
+// Get the consensus address of the proposer of the previous block
+func (k Keeper) GetPreviousProposer(ctx sdk.Context) sdk.ConsAddress {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.ConsAddress(store.Get(types.PreviousProposerKey))
+}
+
+// Set the consensus address of the proposer of the previous block
+func (k Keeper) SetPreviousProposer(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+
+	if consAddr.Empty() {
+		store.Delete(types.PreviousProposerKey)
+		return
+	}
+	store.Set(types.PreviousProposerKey, consAddr)
+}
+
+// Get the rewards accumulated by a validator
+func (k Keeper) GetValidatorRewards(ctx sdk.Context, addr sdk.ValAddress) (rewards sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetValidatorRewardsKey(addr))
+	if bz == nil {
+		return sdk.NewCoins()
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &rewards)
+
+	return rewards
+}
+
+// Set the rewards accumulated by a validator
+func (k Keeper) SetValidatorRewards(ctx sdk.Context, addr sdk.ValAddress, rewards sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+
+	if rewards.IsZero() {
+		store.Delete(types.GetValidatorRewardsKey(addr))
+		return
+	}
+	store.Set(types.GetValidatorRewardsKey(addr), k.cdc.MustMarshalBinaryBare(rewards))
+}
+
+// Get the address receiving the rewards of a validator, the operator account by default
+func (k Keeper) GetWithdrawAddress(ctx sdk.Context, addr sdk.ValAddress) sdk.AccAddress {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.GetWithdrawAddrKey(addr))
+	if bz == nil {
+		return sdk.AccAddress(addr)
+	}
+
+	return sdk.AccAddress(bz)
+}
+
+// Set the address receiving the rewards of a validator
+func (k Keeper) SetWithdrawAddress(ctx sdk.Context, addr sdk.ValAddress, withdrawAddr sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetWithdrawAddrKey(addr), withdrawAddr)
+}
+
+// Get the balance of the poa module account holding the rewards of the validators
+// distributed is false if the rewards are not distributed
+func (k Keeper) GetRewardsPoolBalance(ctx sdk.Context) (balance sdk.Coins, distributed bool) {
+	if k.supplyKeeper == nil {
+		return nil, false
+	}
+
+	return k.supplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins(), true
+}
+
+// Allocate the collected fees and the block reward to the joined validators
+// The proposer of the previous block receives the proposer bonus before the rest is split equally
+// The part that cannot be split equally remains in the fee collector for the next block
+func (k Keeper) AllocateRewards(ctx sdk.Context, previousProposer sdk.ConsAddress) {
+	// The rewards are not distributed without supply keeper
+	if k.supplyKeeper == nil {
+		return
+	}
+
+	// Mint the block reward into the fee collector
+	blockReward := k.BlockReward(ctx)
+	if !blockReward.IsZero() {
+		if err := k.supplyKeeper.MintCoins(ctx, types.ModuleName, blockReward); err != nil {
+			panic(err)
+		}
+		if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, k.feeCollectorName, blockReward); err != nil {
+			panic(err)
+		}
+	}
+
+	collected := k.supplyKeeper.GetModuleAccount(ctx, k.feeCollectorName).GetCoins()
+	if collected.IsZero() {
+		return
+	}
+
+	// Get the validators sharing the rewards
+	var joined []types.Validator
+	for _, validator := range k.GetAllValidators(ctx) {
+		state, found := k.GetValidatorState(ctx, validator.GetOperator())
+		if found && state == types.ValidatorStateJoined {
+			joined = append(joined, validator)
+		}
+	}
+	if len(joined) == 0 {
+		return
+	}
+
+	// Compute the bonus of the previous proposer if it is still a joined validator
+	remaining := collected
+	allocations := make(map[string]sdk.Coins)
+	proposer, found := k.GetValidatorByConsAddr(ctx, previousProposer)
+	if found {
+		state, _ := k.GetValidatorState(ctx, proposer.GetOperator())
+		if state == types.ValidatorStateJoined {
+			bonus, _ := sdk.NewDecCoinsFromCoins(collected...).MulDecTruncate(k.ProposerBonus(ctx)).TruncateDecimal()
+			remaining = remaining.Sub(bonus)
+			allocations[proposer.GetOperator().String()] = bonus
+		}
+	}
+
+	// Split the rest equally
+	var share sdk.Coins
+	for _, coin := range remaining {
+		share = append(share, sdk.NewCoin(coin.Denom, coin.Amount.QuoRaw(int64(len(joined)))))
+	}
+	share = sdk.NewCoins(share...)
+
+	distributed := sdk.NewCoins()
+	for _, validator := range joined {
+		reward := share.Add(allocations[validator.GetOperator().String()]...)
+		if reward.IsZero() {
+			continue
+		}
+
+		k.SetValidatorRewards(ctx, validator.GetOperator(), k.GetValidatorRewards(ctx, validator.GetOperator()).Add(reward...))
+		distributed = distributed.Add(reward...)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeRewards,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+				sdk.NewAttribute(types.AttributeKeyValidator, validator.GetOperator().String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, reward.String()),
+			),
+		)
+	}
+
+	// The poa module account holds the rewards until they are withdrawn
+	if !distributed.IsZero() {
+		if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, k.feeCollectorName, types.ModuleName, distributed); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Send the accumulated rewards of a validator to its withdraw address
+func (k Keeper) WithdrawRewards(ctx sdk.Context, addr sdk.ValAddress) (sdk.Coins, error) {
+	rewards := k.GetValidatorRewards(ctx, addr)
+	if rewards.IsZero() || k.supplyKeeper == nil {
+		return rewards, nil
+	}
+
+	withdrawAddr := k.GetWithdrawAddress(ctx, addr)
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, withdrawAddr, rewards); err != nil {
+		return nil, err
+	}
+	k.SetValidatorRewards(ctx, addr, sdk.NewCoins())
+
+	k.Logger(ctx).Info(fmt.Sprintf("validator %s withdrew %s to %s", addr, rewards, withdrawAddr))
+
+	return rewards, nil
+}
@@ -0,0 +1,112 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/types"
+)
+
+func TestWithdrawAddress(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator, _ := poa.MockValidator()
+	withdrawAddr := sdk.AccAddress(poa.MockValAddress())
+
+	// The operator receives the rewards by default
+	if !poaKeeper.GetWithdrawAddress(ctx, validator.GetOperator()).Equals(sdk.AccAddress(validator.GetOperator())) {
+		t.Errorf("GetWithdrawAddress should return the operator address by default, got %v", poaKeeper.GetWithdrawAddress(ctx, validator.GetOperator()))
+	}
+
+	poaKeeper.SetWithdrawAddress(ctx, validator.GetOperator(), withdrawAddr)
+	if !poaKeeper.GetWithdrawAddress(ctx, validator.GetOperator()).Equals(withdrawAddr) {
+		t.Errorf("GetWithdrawAddress should return %v, got %v", withdrawAddr, poaKeeper.GetWithdrawAddress(ctx, validator.GetOperator()))
+	}
+}
+
+func TestAllocateRewards(t *testing.T) {
+	ctx, poaKeeper, supplyKeeper := poa.MockContextWithSupply()
+	params := types.DefaultParams().WithRewards(sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), sdk.NewDecWithPrec(1, 1))
+	poaKeeper.SetParams(ctx, params)
+
+	proposer, _ := poa.MockValidator()
+	validator, _ := poa.MockValidator()
+	joining, _ := poa.MockValidator()
+	poaKeeper.AppendValidator(ctx, proposer)
+	poaKeeper.AppendValidator(ctx, validator)
+	poaKeeper.AppendValidator(ctx, joining)
+	poaKeeper.SetValidatorState(ctx, proposer, types.ValidatorStateJoined)
+	poaKeeper.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+
+	// Fees collected during the previous block
+	fees := sdk.NewCoins(sdk.NewInt64Coin("stake", 101))
+	if err := supplyKeeper.MintCoins(ctx, types.ModuleName, fees); err != nil {
+		t.Fatalf("Cannot mint fees: %v", err)
+	}
+	if err := supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, auth.FeeCollectorName, fees); err != nil {
+		t.Fatalf("Cannot collect fees: %v", err)
+	}
+
+	poaKeeper.AllocateRewards(ctx, proposer.GetConsAddr())
+
+	// 201 collected, the proposer gets a bonus of 20 and the 181 remaining are split between 2 joined validators
+	expectedProposer := sdk.NewCoins(sdk.NewInt64Coin("stake", 110))
+	if !poaKeeper.GetValidatorRewards(ctx, proposer.GetOperator()).IsEqual(expectedProposer) {
+		t.Errorf("The proposer should receive %v, got %v", expectedProposer, poaKeeper.GetValidatorRewards(ctx, proposer.GetOperator()))
+	}
+	expectedValidator := sdk.NewCoins(sdk.NewInt64Coin("stake", 90))
+	if !poaKeeper.GetValidatorRewards(ctx, validator.GetOperator()).IsEqual(expectedValidator) {
+		t.Errorf("The validator should receive %v, got %v", expectedValidator, poaKeeper.GetValidatorRewards(ctx, validator.GetOperator()))
+	}
+	if !poaKeeper.GetValidatorRewards(ctx, joining.GetOperator()).IsZero() {
+		t.Errorf("A joining validator should not receive rewards, got %v", poaKeeper.GetValidatorRewards(ctx, joining.GetOperator()))
+	}
+
+	// The remainder stays in the fee collector
+	remainder := supplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName).GetCoins()
+	if !remainder.IsEqual(sdk.NewCoins(sdk.NewInt64Coin("stake", 1))) {
+		t.Errorf("The fee collector should keep the remainder of 1stake, got %v", remainder)
+	}
+
+	msg, broken := keeper.RewardsInvariant(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("The poa module account should hold the rewards: %v", msg)
+	}
+}
+
+func TestWithdrawRewards(t *testing.T) {
+	ctx, poaKeeper, supplyKeeper := poa.MockContextWithSupply()
+	params := types.DefaultParams().WithRewards(sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), sdk.ZeroDec())
+	poaKeeper.SetParams(ctx, params)
+
+	validator, _ := poa.MockValidator()
+	withdrawAddr := sdk.AccAddress(poa.MockValAddress())
+	poaKeeper.AppendValidator(ctx, validator)
+	poaKeeper.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+	poaKeeper.SetWithdrawAddress(ctx, validator.GetOperator(), withdrawAddr)
+
+	poaKeeper.AllocateRewards(ctx, validator.GetConsAddr())
+
+	rewards, err := poaKeeper.WithdrawRewards(ctx, validator.GetOperator())
+	if err != nil {
+		t.Fatalf("WithdrawRewards should withdraw the rewards, got error %v", err)
+	}
+	if !rewards.IsEqual(params.BlockReward) {
+		t.Errorf("WithdrawRewards should withdraw %v, got %v", params.BlockReward, rewards)
+	}
+	if !poaKeeper.GetValidatorRewards(ctx, validator.GetOperator()).IsZero() {
+		t.Errorf("WithdrawRewards should reset the rewards, got %v", poaKeeper.GetValidatorRewards(ctx, validator.GetOperator()))
+	}
+
+	// The rewards are sent to the withdraw address
+	balance := supplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins()
+	if !balance.IsZero() {
+		t.Errorf("The poa module account should be empty after the withdrawal, got %v", balance)
+	}
+	if !supplyKeeper.GetSupply(ctx).GetTotal().IsEqual(params.BlockReward) {
+		t.Errorf("The block reward should be minted, total supply is %v", supplyKeeper.GetSupply(ctx).GetTotal())
+	}
+}
@@ -0,0 +1,89 @@
+package keeper
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ltacker/poa/types"
+	"github.com/ltacker/poa/types/pb"
+)
+
+// Type check to ensure the interface is properly implemented
+var _ pb.QueryServer = queryServer{}
+
+// Implementation of the Query service on the keeper
+type queryServer struct {
+	keeper Keeper
+}
+
+// NewQueryServerImpl returns the Query service of the keeper
+// The context of each call must carry the sdk.Context, see types.WrapSDKContext
+func NewQueryServerImpl(k Keeper) pb.QueryServer {
+	return queryServer{
+		keeper: k,
+	}
+}
+
+// Validators queries all the validators
+func (q queryServer) Validators(goCtx context.Context, req *pb.QueryValidatorsRequest) (*pb.QueryValidatorsResponse, error) {
+	ctx := types.UnwrapSDKContext(goCtx)
+
+	res := &pb.QueryValidatorsResponse{}
+	for _, validator := range q.keeper.GetAllValidators(ctx) {
+		res.Validators = append(res.Validators, types.ValidatorWithStateToProto(validatorWithState(ctx, q.keeper, validator)))
+	}
+
+	return res, nil
+}
+
+// Validator queries a validator by operator address
+func (q queryServer) Validator(goCtx context.Context, req *pb.QueryValidatorRequest) (*pb.QueryValidatorResponse, error) {
+	if req == nil || len(req.ValidatorAddr) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty validator address")
+	}
+	ctx := types.UnwrapSDKContext(goCtx)
+
+	validator, found := q.keeper.GetValidator(ctx, req.ValidatorAddr)
+	if !found {
+		return nil, status.Error(codes.NotFound, types.ErrNoValidatorFound.Error())
+	}
+
+	return &pb.QueryValidatorResponse{
+		Validator: types.ValidatorWithStateToProto(validatorWithState(ctx, q.keeper, validator)),
+	}, nil
+}
+
+// Applications queries the applications to become a validator
+func (q queryServer) Applications(goCtx context.Context, req *pb.QueryApplicationsRequest) (*pb.QueryApplicationsResponse, error) {
+	ctx := types.UnwrapSDKContext(goCtx)
+
+	res := &pb.QueryApplicationsResponse{}
+	for _, application := range q.keeper.GetAllApplications(ctx) {
+		res.Applications = append(res.Applications, types.VoteToProto(application))
+	}
+
+	return res, nil
+}
+
+// KickProposals queries the proposals to kick a validator
+func (q queryServer) KickProposals(goCtx context.Context, req *pb.QueryKickProposalsRequest) (*pb.QueryKickProposalsResponse, error) {
+	ctx := types.UnwrapSDKContext(goCtx)
+
+	res := &pb.QueryKickProposalsResponse{}
+	for _, kickProposal := range q.keeper.GetAllKickProposals(ctx) {
+		res.KickProposals = append(res.KickProposals, types.VoteToProto(kickProposal))
+	}
+
+	return res, nil
+}
+
+// Params queries the params of the module
+func (q queryServer) Params(goCtx context.Context, req *pb.QueryParamsRequest) (*pb.QueryParamsResponse, error) {
+	ctx := types.UnwrapSDKContext(goCtx)
+
+	return &pb.QueryParamsResponse{
+		Params: types.ParamsToProto(q.keeper.GetParams(ctx)),
+	}, nil
+}
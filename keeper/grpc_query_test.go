@@ -0,0 +1,73 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/types"
+	"github.com/ltacker/poa/types/pb"
+)
+
+func TestQueryServerValidators(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	queryServer := keeper.NewQueryServerImpl(poaKeeper)
+	goCtx := types.WrapSDKContext(ctx)
+	validator, _ := poa.MockValidator()
+	poaKeeper.AppendValidator(ctx, validator)
+
+	res, err := queryServer.Validators(goCtx, &pb.QueryValidatorsRequest{})
+	if err != nil {
+		t.Fatalf("Validators should return the validators, got error %v", err)
+	}
+	if len(res.Validators) != 1 {
+		t.Fatalf("Validators should return 1 validator, got %v", len(res.Validators))
+	}
+	if !cmp.Equal(types.ValidatorFromProto(res.Validators[0].Validator), validator) {
+		t.Errorf("Validators should return %v, got %v", validator, res.Validators[0].Validator)
+	}
+	if res.Validators[0].State != "joining" {
+		t.Errorf("Validators should return the joining state, got %v", res.Validators[0].State)
+	}
+
+	// A validator is queried by operator address
+	validatorRes, err := queryServer.Validator(goCtx, &pb.QueryValidatorRequest{ValidatorAddr: validator.GetOperator()})
+	if err != nil {
+		t.Fatalf("Validator should return the validator, got error %v", err)
+	}
+	if !cmp.Equal(types.ValidatorFromProto(validatorRes.Validator.Validator), validator) {
+		t.Errorf("Validator should return %v, got %v", validator, validatorRes.Validator.Validator)
+	}
+	_, err = queryServer.Validator(goCtx, &pb.QueryValidatorRequest{ValidatorAddr: poa.MockValAddress()})
+	if err == nil {
+		t.Errorf("Validator should fail for an unknown validator")
+	}
+}
+
+func TestQueryServerApplicationsAndParams(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	queryServer := keeper.NewQueryServerImpl(poaKeeper)
+	goCtx := types.WrapSDKContext(ctx)
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	candidate, _ := poa.MockValidator()
+	poaKeeper.SetApplication(ctx, types.NewVote(candidate))
+
+	res, err := queryServer.Applications(goCtx, &pb.QueryApplicationsRequest{})
+	if err != nil {
+		t.Fatalf("Applications should return the applications, got error %v", err)
+	}
+	if len(res.Applications) != 1 || !cmp.Equal(types.ValidatorFromProto(res.Applications[0].Subject), candidate) {
+		t.Errorf("Applications should return the application of %v, got %v", candidate, res.Applications)
+	}
+
+	paramsRes, err := queryServer.Params(goCtx, &pb.QueryParamsRequest{})
+	if err != nil {
+		t.Fatalf("Params should return the params, got error %v", err)
+	}
+	if paramsRes.Params.MaxValidators != uint32(types.DefaultMaxValidators) || paramsRes.Params.Quorum != uint32(types.DefaultQuorum) ||
+		paramsRes.Params.MaxMissedVotes != types.DefaultMaxMissedVotes {
+		t.Errorf("Params should return the default params, got %v", paramsRes.Params)
+	}
+}
@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ltacker/poa/types"
+)
+
+// Get the id of the next closed proposal
+func (k Keeper) getNextClosedProposalID(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.NextClosedProposalIDKey)
+	if value == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(value)
+}
+
+// Get a closed proposal
+func (k Keeper) GetClosedProposal(ctx sdk.Context, id uint64) (closedProposal types.ClosedProposal, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.GetClosedProposalKey(id))
+	if value == nil {
+		return closedProposal, false
+	}
+
+	return types.MustUnmarshalClosedProposal(k.cdc, value), true
+}
+
+// Archive a vote with its final tally and outcome
+// The eligible validators who didn't vote miss the vote if the proposal expired
+func (k Keeper) ArchiveProposal(ctx sdk.Context, proposalType uint16, vote types.Vote, outcome string) types.ClosedProposal {
+	if !types.IsValidProposalOutcome(outcome) {
+		panic("Incorrect proposal outcome")
+	}
+	k.RecordMissedVotes(ctx, proposalType, vote, outcome)
+
+	id := k.getNextClosedProposalID(ctx)
+	votes := k.GetAllVoterChoices(ctx, proposalType, vote.GetSubject().GetOperator())
+	closedProposal := types.NewClosedProposal(id, proposalType, vote, votes, outcome, ctx.BlockHeight())
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetClosedProposalKey(id), types.MustMarshalClosedProposal(k.cdc, closedProposal))
+	store.Set(types.NextClosedProposalIDKey, sdk.Uint64ToBigEndian(id+1))
+
+	return closedProposal
+}
+
+// Get all the closed proposals, from the oldest to the most recent
+func (k Keeper) GetAllClosedProposals(ctx sdk.Context) (closedProposals []types.ClosedProposal) {
+	store := ctx.KVStore(k.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, types.ProposalHistoryKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		closedProposal := types.MustUnmarshalClosedProposal(k.cdc, iterator.Value())
+		closedProposals = append(closedProposals, closedProposal)
+	}
+
+	return closedProposals
+}
+
+// Archive an application with its final tally and outcome and remove it
+func (k Keeper) CloseApplication(ctx sdk.Context, application types.Vote, outcome string) {
+	k.ArchiveProposal(ctx, types.VoteTypeApplication, application, outcome)
+	k.RemoveApplication(ctx, application.GetSubject().GetOperator())
+}
+
+// Archive a kick proposal with its final tally and outcome and remove it
+func (k Keeper) CloseKickProposal(ctx sdk.Context, kickProposal types.Vote, outcome string) {
+	k.ArchiveProposal(ctx, types.VoteTypeKickProposal, kickProposal, outcome)
+	k.RemoveKickProposal(ctx, kickProposal.GetSubject().GetOperator())
+}
@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ltacker/poa/types"
+)
+
+// Implements PoaHooks
+var _ types.PoaHooks = Keeper{}
+
+// AfterApplicationSubmitted - call hook if registered
+func (k Keeper) AfterApplicationSubmitted(ctx sdk.Context, candidateAddr sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterApplicationSubmitted(ctx, candidateAddr)
+	}
+}
+
+// AfterValidatorAppended - call hook if registered
+func (k Keeper) AfterValidatorAppended(ctx sdk.Context, valAddr sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterValidatorAppended(ctx, valAddr)
+	}
+}
+
+// AfterValidatorJoined - call hook if registered
+func (k Keeper) AfterValidatorJoined(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterValidatorJoined(ctx, consAddr, valAddr)
+	}
+}
+
+// AfterKickProposed - call hook if registered
+func (k Keeper) AfterKickProposed(ctx sdk.Context, candidateAddr sdk.ValAddress, proposerAddr sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterKickProposed(ctx, candidateAddr, proposerAddr)
+	}
+}
+
+// BeforeValidatorRemoved - call hook if registered
+func (k Keeper) BeforeValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.BeforeValidatorRemoved(ctx, consAddr, valAddr)
+	}
+}
+
+// AfterValidatorRemoved - call hook if registered
+func (k Keeper) AfterValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterValidatorRemoved(ctx, consAddr, valAddr)
+	}
+}
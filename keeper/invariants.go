@@ -0,0 +1,318 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ltacker/poa/types"
+)
+
+// RegisterInvariants registers all poa invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "validator-states",
+		ValidatorStatesInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "validator-counts",
+		ValidatorCountsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "validators-by-cons-addr",
+		ValidatorsByConsAddrInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "applications-by-cons-addr",
+		ApplicationsByConsAddrInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "validator-not-applying",
+		ValidatorNotApplyingInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "max-validators",
+		MaxValidatorsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "vote-totals",
+		VoteTotalsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "rewards",
+		RewardsInvariant(k))
+}
+
+// AllInvariants runs all invariants of the poa module.
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := ValidatorStatesInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		res, stop = ValidatorCountsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		res, stop = ValidatorsByConsAddrInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		res, stop = ApplicationsByConsAddrInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		res, stop = ValidatorNotApplyingInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		res, stop = MaxValidatorsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		res, stop = VoteTotalsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+
+		return RewardsInvariant(k)(ctx)
+	}
+}
+
+// ValidatorStatesInvariant checks that every validator has a state
+// and that every state belongs to a validator
+func ValidatorStatesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		for _, validator := range k.GetAllValidators(ctx) {
+			if _, found := k.GetValidatorState(ctx, validator.GetOperator()); !found {
+				count++
+				msg += fmt.Sprintf("\tvalidator %s has no state\n", validator.GetOperator())
+			}
+		}
+
+		store := ctx.KVStore(k.storeKey)
+		iterator := sdk.KVStorePrefixIterator(store, types.ValidatorStatesKey)
+		defer iterator.Close()
+
+		for ; iterator.Valid(); iterator.Next() {
+			operatorAddr := sdk.ValAddress(iterator.Key()[len(types.ValidatorStatesKey):])
+			if _, found := k.GetValidator(ctx, operatorAddr); !found {
+				count++
+				msg += fmt.Sprintf("\tstate found for %s which is not a validator\n", operatorAddr)
+			}
+		}
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, "validator states", fmt.Sprintf(
+			"%d validator state inconsistencies found\n%s", count, msg)), broken
+	}
+}
+
+// ValidatorCountsInvariant checks that the counters of validators match the validator set
+func ValidatorCountsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		stateCounts := make(map[uint16]uint64)
+		validators := k.GetAllValidators(ctx)
+		for _, validator := range validators {
+			state, _ := k.GetValidatorState(ctx, validator.GetOperator())
+			stateCounts[state]++
+		}
+
+		if k.GetValidatorCount(ctx) != uint64(len(validators)) {
+			count++
+			msg += fmt.Sprintf("\tvalidator count is %d with %d validators\n", k.GetValidatorCount(ctx), len(validators))
+		}
+		for _, state := range []uint16{types.ValidatorStateJoining, types.ValidatorStateJoined, types.ValidatorStateLeaving,
+			types.ValidatorStateJailing, types.ValidatorStateJailed} {
+			if k.GetValidatorStateCount(ctx, state) != stateCounts[state] {
+				count++
+				msg += fmt.Sprintf("\t%s validator count is %d with %d validators\n", types.ValidatorStateToString(state), k.GetValidatorStateCount(ctx, state), stateCounts[state])
+			}
+		}
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, "validator counts", fmt.Sprintf(
+			"%d incorrect validator counts found\n%s", count, msg)), broken
+	}
+}
+
+// ValidatorsByConsAddrInvariant checks that every entry of the validator consensus address index
+// points to an existing validator with the same consensus address
+func ValidatorsByConsAddrInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		store := ctx.KVStore(k.storeKey)
+		iterator := sdk.KVStorePrefixIterator(store, types.ValidatorsByConsAddrKey)
+		defer iterator.Close()
+
+		for ; iterator.Valid(); iterator.Next() {
+			consAddr := sdk.ConsAddress(iterator.Key()[len(types.ValidatorsByConsAddrKey):])
+			operatorAddr := sdk.ValAddress(iterator.Value())
+
+			validator, found := k.GetValidator(ctx, operatorAddr)
+			if !found {
+				count++
+				msg += fmt.Sprintf("\tconsensus address %s points to a non existing validator %s\n", consAddr, operatorAddr)
+				continue
+			}
+			if !validator.GetConsAddr().Equals(consAddr) {
+				count++
+				msg += fmt.Sprintf("\tconsensus address %s points to validator %s with consensus address %s\n", consAddr, operatorAddr, validator.GetConsAddr())
+			}
+		}
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, "validators by consensus address", fmt.Sprintf(
+			"%d invalid validator consensus address indexes found\n%s", count, msg)), broken
+	}
+}
+
+// ApplicationsByConsAddrInvariant checks that every entry of the application consensus address index
+// points to an existing application with the same consensus address
+func ApplicationsByConsAddrInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		store := ctx.KVStore(k.storeKey)
+		iterator := sdk.KVStorePrefixIterator(store, types.ApplicationByConsAddrKey)
+		defer iterator.Close()
+
+		for ; iterator.Valid(); iterator.Next() {
+			consAddr := sdk.ConsAddress(iterator.Key()[len(types.ApplicationByConsAddrKey):])
+			operatorAddr := sdk.ValAddress(iterator.Value())
+
+			application, found := k.GetApplication(ctx, operatorAddr)
+			if !found {
+				count++
+				msg += fmt.Sprintf("\tconsensus address %s points to a non existing application %s\n", consAddr, operatorAddr)
+				continue
+			}
+			if !application.GetSubject().GetConsAddr().Equals(consAddr) {
+				count++
+				msg += fmt.Sprintf("\tconsensus address %s points to application %s with consensus address %s\n", consAddr, operatorAddr, application.GetSubject().GetConsAddr())
+			}
+		}
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, "applications by consensus address", fmt.Sprintf(
+			"%d invalid application consensus address indexes found\n%s", count, msg)), broken
+	}
+}
+
+// ValidatorNotApplyingInvariant checks that no operator is both a validator and a candidate in the application pool
+func ValidatorNotApplyingInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		for _, application := range k.GetAllApplications(ctx) {
+			operatorAddr := application.GetSubject().GetOperator()
+			if _, found := k.GetValidator(ctx, operatorAddr); found {
+				count++
+				msg += fmt.Sprintf("\t%s is both a validator and applying\n", operatorAddr)
+			}
+		}
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, "validator not applying", fmt.Sprintf(
+			"%d validators found in the application pool\n%s", count, msg)), broken
+	}
+}
+
+// MaxValidatorsInvariant checks that the number of validators doesn't exceed the maximum number of validators
+// The leaving validators are not counted, the excess validators are set as leaving by the end blocker when the param is
+// lowered. The last bonded validator is never removed and can exceed the maximum
+func MaxValidatorsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var validatorCount, bondedCount int
+		for _, validator := range k.GetAllValidators(ctx) {
+			state, _ := k.GetValidatorState(ctx, validator.GetOperator())
+			if state == types.ValidatorStateLeaving {
+				continue
+			}
+			validatorCount++
+			if state == types.ValidatorStateJoined || state == types.ValidatorStateJoining {
+				bondedCount++
+			}
+		}
+		maxValidators := k.MaxValidators(ctx)
+
+		broken := validatorCount > int(maxValidators) && !(validatorCount == 1 && bondedCount == 1)
+
+		return sdk.FormatInvariant(types.ModuleName, "max validators", fmt.Sprintf(
+			"validator count: %d, max validators: %d\n", validatorCount, maxValidators)), broken
+	}
+}
+
+// VoteTotalsInvariant checks that the tally counters of every application and kick proposal vote match the choices of its voters
+func VoteTotalsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		for _, application := range k.GetAllApplications(ctx) {
+			approvals, unknown, total := countVoterChoices(ctx, k, types.VoteTypeApplication, application)
+			if !talliesMatch(application, approvals, unknown, total) {
+				count++
+				msg += fmt.Sprintf("\tapplication %s has %d approvals out of %d with %d approvals out of %d voters\n", application.GetSubject().GetOperator(), application.GetApprovals(), application.GetTotal(), approvals, total)
+			}
+		}
+		for _, kickProposal := range k.GetAllKickProposals(ctx) {
+			approvals, unknown, total := countVoterChoices(ctx, k, types.VoteTypeKickProposal, kickProposal)
+			if !talliesMatch(kickProposal, approvals, unknown, total) {
+				count++
+				msg += fmt.Sprintf("\tkick proposal %s has %d approvals out of %d with %d approvals out of %d voters\n", kickProposal.GetSubject().GetOperator(), kickProposal.GetApprovals(), kickProposal.GetTotal(), approvals, total)
+			}
+		}
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, "vote totals", fmt.Sprintf(
+			"%d votes with an incorrect total found\n%s", count, msg)), broken
+	}
+}
+
+// Count the approvals, the unknown choices and the voters from the choices stored for a vote
+func countVoterChoices(ctx sdk.Context, k Keeper, voteType uint16, vote types.Vote) (approvals uint64, unknown uint64, total uint64) {
+	k.IterateVoterChoices(ctx, voteType, vote.GetSubject().GetOperator(), func(choice types.VoterChoice) bool {
+		total++
+		if choice.Unknown {
+			unknown++
+		} else if choice.Approve {
+			approvals++
+		}
+		return false
+	})
+
+	return approvals, unknown, total
+}
+
+// Check if the tally counters of a vote match the choices stored
+// An unknown choice, migrated from an old store, may be either an approval or a rejection
+func talliesMatch(vote types.Vote, approvals uint64, unknown uint64, total uint64) bool {
+	return vote.GetTotal() == total && vote.GetApprovals() >= approvals && vote.GetApprovals() <= approvals+unknown
+}
+
+// RewardsInvariant checks that the poa module account holds the rewards of all the validators
+func RewardsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		if k.supplyKeeper == nil {
+			return sdk.FormatInvariant(types.ModuleName, "rewards", "rewards not distributed\n"), false
+		}
+
+		total := sdk.NewCoins()
+		for _, validator := range k.GetAllValidators(ctx) {
+			total = total.Add(k.GetValidatorRewards(ctx, validator.GetOperator())...)
+		}
+
+		balance := k.supplyKeeper.GetModuleAccount(ctx, types.ModuleName).GetCoins()
+		broken := !balance.IsAllGTE(total)
+
+		return sdk.FormatInvariant(types.ModuleName, "rewards",
+			fmt.Sprintf("\tpoa module account balance: %s\n\tsum of validator rewards: %s\n", balance, total)), broken
+	}
+}
@@ -0,0 +1,208 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/types"
+)
+
+func TestAllInvariants(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	candidate, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.AppendValidator(ctx, validator2)
+	poaKeeper.AppendApplication(ctx, candidate)
+	poaKeeper.AppendKickProposal(ctx, validator2)
+
+	// A consistent store doesn't break any invariant
+	msg, broken := keeper.AllInvariants(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("AllInvariants should not be broken for a consistent store: %v", msg)
+	}
+}
+
+func TestValidatorStatesInvariant(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, validator1)
+
+	_, broken := keeper.ValidatorStatesInvariant(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("ValidatorStatesInvariant should not be broken if all validators have a state")
+	}
+
+	// A validator without state breaks the invariant
+	poaKeeper.SetValidator(ctx, validator2)
+	_, broken = keeper.ValidatorStatesInvariant(poaKeeper)(ctx)
+	if !broken {
+		t.Errorf("ValidatorStatesInvariant should be broken if a validator has no state")
+	}
+
+	// A state without validator breaks the invariant
+	ctx, poaKeeper = poa.MockContext()
+	poaKeeper.SetValidatorState(ctx, validator1, types.ValidatorStateJoined)
+	_, broken = keeper.ValidatorStatesInvariant(poaKeeper)(ctx)
+	if !broken {
+		t.Errorf("ValidatorStatesInvariant should be broken if a state has no validator")
+	}
+}
+
+func TestValidatorCountsInvariant(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.SetValidatorState(ctx, validator1, types.ValidatorStateJoined)
+
+	_, broken := keeper.ValidatorCountsInvariant(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("ValidatorCountsInvariant should not be broken if the counters match the validators")
+	}
+
+	// A validator set without its state is not counted
+	poaKeeper.SetValidator(ctx, validator2)
+	_, broken = keeper.ValidatorCountsInvariant(poaKeeper)(ctx)
+	if !broken {
+		t.Errorf("ValidatorCountsInvariant should be broken if a validator is not counted")
+	}
+}
+
+func TestValidatorsByConsAddrInvariant(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, validator1)
+
+	_, broken := keeper.ValidatorsByConsAddrInvariant(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("ValidatorsByConsAddrInvariant should not be broken if all indexes are correct")
+	}
+
+	// An index pointing to a non existing validator breaks the invariant
+	poaKeeper.SetValidatorByConsAddr(ctx, validator2)
+	_, broken = keeper.ValidatorsByConsAddrInvariant(poaKeeper)(ctx)
+	if !broken {
+		t.Errorf("ValidatorsByConsAddrInvariant should be broken if an index points to a non existing validator")
+	}
+}
+
+func TestApplicationsByConsAddrInvariant(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	candidate1, _ := poa.MockValidator()
+	candidate2, _ := poa.MockValidator()
+
+	poaKeeper.AppendApplication(ctx, candidate1)
+
+	_, broken := keeper.ApplicationsByConsAddrInvariant(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("ApplicationsByConsAddrInvariant should not be broken if all indexes are correct")
+	}
+
+	// An index pointing to a non existing application breaks the invariant
+	poaKeeper.SetApplicationByConsAddr(ctx, types.NewVote(candidate2))
+	_, broken = keeper.ApplicationsByConsAddrInvariant(poaKeeper)(ctx)
+	if !broken {
+		t.Errorf("ApplicationsByConsAddrInvariant should be broken if an index points to a non existing application")
+	}
+}
+
+func TestValidatorNotApplyingInvariant(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator, _ := poa.MockValidator()
+	candidate, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, validator)
+	poaKeeper.AppendApplication(ctx, candidate)
+
+	_, broken := keeper.ValidatorNotApplyingInvariant(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("ValidatorNotApplyingInvariant should not be broken if no validator is applying")
+	}
+
+	// A validator in the application pool breaks the invariant
+	poaKeeper.AppendApplication(ctx, validator)
+	_, broken = keeper.ValidatorNotApplyingInvariant(poaKeeper)(ctx)
+	if !broken {
+		t.Errorf("ValidatorNotApplyingInvariant should be broken if a validator is applying")
+	}
+}
+
+func TestMaxValidatorsInvariant(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.NewParams(1, 66))
+
+	poaKeeper.AppendValidator(ctx, validator1)
+
+	_, broken := keeper.MaxValidatorsInvariant(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("MaxValidatorsInvariant should not be broken if the validator count is max validators")
+	}
+
+	// More validators than max validators breaks the invariant
+	poaKeeper.AppendValidator(ctx, validator2)
+	_, broken = keeper.MaxValidatorsInvariant(poaKeeper)(ctx)
+	if !broken {
+		t.Errorf("MaxValidatorsInvariant should be broken if the validator count exceeds max validators")
+	}
+
+	// The leaving validators are not counted
+	poaKeeper.SetValidatorState(ctx, validator2, types.ValidatorStateLeaving)
+	_, broken = keeper.MaxValidatorsInvariant(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("MaxValidatorsInvariant should not count the leaving validators")
+	}
+}
+
+func TestMaxValidatorsInvariantLoweredParam(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	validator3, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.NewParams(3, 66))
+	for _, validator := range []types.Validator{validator1, validator2, validator3} {
+		poaKeeper.AppendValidator(ctx, validator)
+		poaKeeper.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+	}
+
+	// The param is lowered below the number of validators, the end blocker removes the excess validators
+	poaKeeper.SetParams(ctx, types.NewParams(1, 66))
+	poa.EndBlocker(ctx, poaKeeper)
+	if msg, broken := keeper.MaxValidatorsInvariant(poaKeeper)(ctx); broken {
+		t.Errorf("MaxValidatorsInvariant should not be broken after the end blocker removed the excess validators: %v", msg)
+	}
+}
+
+func TestVoteTotalsInvariant(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	candidate, _ := poa.MockValidator()
+	voter := poa.MockValAddress()
+
+	application := types.NewVote(candidate)
+	poaKeeper.AddVote(ctx, types.VoteTypeApplication, &application, voter, true)
+	poaKeeper.SetApplication(ctx, application)
+
+	_, broken := keeper.VoteTotalsInvariant(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("VoteTotalsInvariant should not be broken if totals match the voters")
+	}
+
+	// A total that doesn't match the voters breaks the invariant
+	application.Total = 2
+	poaKeeper.SetKickProposal(ctx, application)
+	_, broken = keeper.VoteTotalsInvariant(poaKeeper)(ctx)
+	if !broken {
+		t.Errorf("VoteTotalsInvariant should be broken if a total doesn't match the voters")
+	}
+}
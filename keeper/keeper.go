@@ -12,21 +12,36 @@ import (
 
 // Keeper of the poa store
 type Keeper struct {
-	storeKey   sdk.StoreKey
-	cdc        *codec.Codec
-	paramspace types.ParamSubspace
+	storeKey         sdk.StoreKey
+	cdc              *codec.Codec
+	paramspace       types.ParamSubspace
+	supplyKeeper     types.SupplyKeeper
+	feeCollectorName string
+	hooks            types.PoaHooks
 }
 
 // NewKeeper creates a poa keeper
-func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramspace types.ParamSubspace) Keeper {
+// The supply keeper can be nil, the rewards are then not distributed to the validators
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramspace types.ParamSubspace, supplyKeeper types.SupplyKeeper, feeCollectorName string) Keeper {
 	keeper := Keeper{
-		storeKey:   key,
-		cdc:        cdc,
-		paramspace: paramspace.WithKeyTable(types.ParamKeyTable()),
+		storeKey:         key,
+		cdc:              cdc,
+		paramspace:       paramspace.WithKeyTable(types.ParamKeyTable()),
+		supplyKeeper:     supplyKeeper,
+		feeCollectorName: feeCollectorName,
 	}
 	return keeper
 }
 
+// Set the validator hooks
+func (k *Keeper) SetHooks(ph types.PoaHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set validator hooks twice")
+	}
+	k.hooks = ph
+	return k
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
@@ -27,10 +27,35 @@ func (k Keeper) SetKickProposal(ctx sdk.Context, kickProposal types.Vote) {
 	store.Set(types.GetKickProposalKey(kickProposal.GetSubject().GetOperator()), bz)
 }
 
+// Get the reason of the kick proposal against a validator
+func (k Keeper) GetKickProposalReason(ctx sdk.Context, addr sdk.ValAddress) uint16 {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.GetKickProposalReasonKey(addr))
+	if value == nil {
+		return types.KickReasonProposed
+	}
+
+	return uint16(value[0]) // A single byte represents the reason
+}
+
+// Set the reason of the kick proposal against a validator
+func (k Keeper) SetKickProposalReason(ctx sdk.Context, addr sdk.ValAddress, reason uint16) {
+	store := ctx.KVStore(k.storeKey)
+
+	// The default reason is not stored
+	if reason == types.KickReasonProposed {
+		store.Delete(types.GetKickProposalReasonKey(addr))
+		return
+	}
+	store.Set(types.GetKickProposalReasonKey(addr), []byte{byte(reason)})
+}
+
 // Append a new kick proposal with a new vote
 func (k Keeper) AppendKickProposal(ctx sdk.Context, candidate types.Validator) {
 	kickProposalNewVote := types.NewVote(candidate)
 	k.SetKickProposal(ctx, kickProposalNewVote)
+	k.setProposalOpenHeight(ctx, types.VoteTypeKickProposal, candidate.GetOperator())
 }
 
 // Remove the kick proposal
@@ -43,6 +68,9 @@ func (k Keeper) RemoveKickProposal(ctx sdk.Context, address sdk.ValAddress) {
 	// Delete the kick proposal record
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(types.GetKickProposalKey(address))
+	store.Delete(types.GetKickProposalReasonKey(address))
+	store.Delete(types.GetProposalOpenHeightKey(types.VoteTypeKickProposal, address))
+	k.removeVoterChoices(ctx, types.VoteTypeKickProposal, address)
 }
 
 // Get the set of all kick proposals
@@ -0,0 +1,248 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ltacker/poa/types"
+)
+
+// The consensus version of a store created before the version was stored
+const legacyConsensusVersion uint64 = 1
+
+// A migration upgrades the store from a consensus version to the next one
+type migration func(ctx sdk.Context, k Keeper) error
+
+// Registered migrations, indexed by the consensus version they upgrade from
+var migrations = map[uint64]migration{
+	1: migrateDistributionParams,
+	2: migrateMaxMissedVotesParam,
+	3: migrateVoterChoices,
+	4: migrateValidatorCounts,
+	5: migrateOutOfSetMarkers,
+	6: migrateValidatorStateHeights,
+	7: migrateRemovedValidatorQueue,
+	8: migrateValidatorJoinHeights,
+	9: migrateProposalOpenHeights,
+}
+
+// Get the consensus version of the store
+func (k Keeper) GetConsensusVersion(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.ConsensusVersionKey)
+	if value == nil {
+		return legacyConsensusVersion
+	}
+
+	return binary.BigEndian.Uint64(value)
+}
+
+// Set the consensus version of the store
+func (k Keeper) SetConsensusVersion(ctx sdk.Context, version uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.ConsensusVersionKey, sdk.Uint64ToBigEndian(version))
+}
+
+// Migrator upgrades the poa store to the current consensus version
+// It is meant to be called from a x/upgrade handler:
+//
+//	upgradeKeeper.SetUpgradeHandler("name", func(ctx sdk.Context, plan upgrade.Plan) {
+//		if err := keeper.NewMigrator(poaKeeper).Migrate(ctx); err != nil {
+//			panic(err)
+//		}
+//	})
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator
+func NewMigrator(k Keeper) Migrator {
+	return Migrator{
+		keeper: k,
+	}
+}
+
+// Migrate runs all the migrations from the consensus version of the store to the current consensus version
+func (m Migrator) Migrate(ctx sdk.Context) error {
+	version := m.keeper.GetConsensusVersion(ctx)
+	if version > types.ConsensusVersion {
+		return fmt.Errorf("store consensus version %d is newer than the module consensus version %d", version, types.ConsensusVersion)
+	}
+
+	for ; version < types.ConsensusVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return fmt.Errorf("no migration registered from consensus version %d", version)
+		}
+		if err := migrate(ctx, m.keeper); err != nil {
+			return fmt.Errorf("migration from consensus version %d failed: %v", version, err)
+		}
+
+		m.keeper.Logger(ctx).Info(fmt.Sprintf("poa store migrated to consensus version %d", version+1))
+	}
+
+	m.keeper.SetConsensusVersion(ctx, types.ConsensusVersion)
+	return nil
+}
+
+// Consensus version 2 adds the distribution params, they are set to their default values
+func migrateDistributionParams(ctx sdk.Context, k Keeper) error {
+	defaultParams := types.DefaultParams()
+
+	if !k.paramspace.Has(ctx, types.KeyBlockReward) {
+		k.paramspace.Set(ctx, types.KeyBlockReward, defaultParams.BlockReward)
+	}
+	if !k.paramspace.Has(ctx, types.KeyProposerBonus) {
+		k.paramspace.Set(ctx, types.KeyProposerBonus, defaultParams.ProposerBonus)
+	}
+
+	return nil
+}
+
+// Consensus version 3 adds the max missed votes param, it is set to its default value
+func migrateMaxMissedVotesParam(ctx sdk.Context, k Keeper) error {
+	if !k.paramspace.Has(ctx, types.KeyMaxMissedVotes) {
+		k.paramspace.Set(ctx, types.KeyMaxMissedVotes, types.DefaultMaxMissedVotes)
+	}
+
+	return nil
+}
+
+// Layout of a vote before consensus version 4, the voters were stored in the vote
+type legacyVote struct {
+	Subject   types.Validator
+	Approvals uint64
+	Total     uint64
+	Voters    []sdk.ValAddress
+	Approvers []sdk.ValAddress
+}
+
+// Consensus version 4 stores the choice of each voter under its own key
+// The votes only keep their tally counters
+func migrateVoterChoices(ctx sdk.Context, k Keeper) error {
+	store := ctx.KVStore(k.storeKey)
+
+	for _, voteType := range []uint16{types.VoteTypeApplication, types.VoteTypeKickProposal} {
+		poolKey := types.ApplicationPoolKey
+		if voteType == types.VoteTypeKickProposal {
+			poolKey = types.KickProposalPoolKey
+		}
+
+		// The votes are rewritten after the iteration
+		var legacyVotes []legacyVote
+		iterator := sdk.KVStorePrefixIterator(store, poolKey)
+		for ; iterator.Valid(); iterator.Next() {
+			var vote legacyVote
+			if err := k.cdc.UnmarshalBinaryBare(iterator.Value(), &vote); err != nil {
+				iterator.Close()
+				return fmt.Errorf("cannot decode the vote %X: %v", iterator.Key(), err)
+			}
+			legacyVotes = append(legacyVotes, vote)
+		}
+		iterator.Close()
+
+		for _, legacy := range legacyVotes {
+			vote := types.NewVote(legacy.Subject)
+			vote.Approvals = legacy.Approvals
+			vote.Total = legacy.Total
+			for _, choice := range legacyVoterChoices(legacy) {
+				k.SetVoterChoice(ctx, voteType, vote.GetSubject().GetOperator(), choice)
+			}
+
+			store.Set(types.GetProposalKey(voteType, vote.GetSubject().GetOperator()), types.MustMarshalVote(k.cdc, vote))
+		}
+	}
+
+	return nil
+}
+
+// Consensus version 5 adds the counters of validators, they are computed from the validator set
+func migrateValidatorCounts(ctx sdk.Context, k Keeper) error {
+	var count uint64
+	stateCounts := make(map[uint16]uint64)
+	for _, validator := range k.GetAllValidators(ctx) {
+		state, found := k.GetValidatorState(ctx, validator.GetOperator())
+		if !found {
+			return fmt.Errorf("validator %s has no state", validator.GetOperator())
+		}
+		count++
+		stateCounts[state]++
+	}
+
+	k.setValidatorCount(ctx, count)
+	for state, stateCount := range stateCounts {
+		k.setValidatorStateCount(ctx, state, stateCount)
+	}
+
+	return nil
+}
+
+// Consensus version 6 checks the marker of the validators removed from Tendermint validator set
+// A jailed validator is always out of the set, the marker is set if it is missing
+func migrateOutOfSetMarkers(ctx sdk.Context, k Keeper) error {
+	for _, validator := range k.GetAllValidators(ctx) {
+		state, found := k.GetValidatorState(ctx, validator.GetOperator())
+		if !found {
+			return fmt.Errorf("validator %s has no state", validator.GetOperator())
+		}
+		if state == types.ValidatorStateJailed && !k.IsValidatorOutOfSet(ctx, validator.GetOperator()) {
+			k.SetValidatorOutOfSet(ctx, validator.GetOperator())
+		}
+	}
+
+	return nil
+}
+
+// Consensus version 7 records the height each validator entered its state
+// The height is not known for a validator that has not changed state since the upgrade, it is left unset like the
+// height of a genesis validator
+func migrateValidatorStateHeights(_ sdk.Context, _ Keeper) error {
+	return nil
+}
+
+// Consensus version 8 queues the removed validators until they leave the last commit
+// The queue starts empty, the validators removed before the upgrade are no longer in the last commit
+func migrateRemovedValidatorQueue(_ sdk.Context, _ Keeper) error {
+	return nil
+}
+
+// Consensus version 9 records the height each validator joined the validator set
+// The height is not known for a validator appended before the upgrade, it is left unset like the height of a genesis
+// validator. The state height is not the join height, a validator can change state after it joined
+func migrateValidatorJoinHeights(_ sdk.Context, _ Keeper) error {
+	return nil
+}
+
+// Consensus version 10 records the height each application and kick proposal has been opened
+// The height is not known for a proposal opened before the upgrade, it is left unset and no validator misses its vote
+func migrateProposalOpenHeights(_ sdk.Context, _ Keeper) error {
+	return nil
+}
+
+// Get the choices of the voters of a legacy vote
+// The approvers were not recorded before the proposal history, the choices are then only known if all the voters
+// made the same choice, otherwise they are recorded as unknown. The tally counters of the vote are kept as they are
+func legacyVoterChoices(vote legacyVote) []types.VoterChoice {
+	tracked := uint64(len(vote.Approvers)) == vote.Approvals
+	approvers := make(map[string]bool, len(vote.Approvers))
+	for _, approver := range vote.Approvers {
+		approvers[approver.String()] = true
+	}
+
+	choices := make([]types.VoterChoice, len(vote.Voters))
+	for i, voter := range vote.Voters {
+		choices[i] = types.VoterChoice{Voter: voter}
+		switch {
+		case tracked:
+			choices[i].Approve = approvers[voter.String()]
+		case vote.Approvals == vote.Total:
+			choices[i].Approve = true
+		case vote.Approvals != 0:
+			choices[i].Unknown = true
+		}
+	}
+
+	return choices
+}
@@ -0,0 +1,329 @@
+package keeper_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/types"
+)
+
+// A raw store entry of a fixture
+type storeFixtureEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Load a fixture of raw store entries in the poa store
+func loadStoreFixture(t *testing.T, ctx sdk.Context, storeKey sdk.StoreKey, path string) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Cannot read fixture %v: %v", path, err)
+	}
+
+	var entries []storeFixtureEntry
+	if err := json.Unmarshal(bz, &entries); err != nil {
+		t.Fatalf("Cannot decode fixture %v: %v", path, err)
+	}
+
+	store := ctx.KVStore(storeKey)
+	for _, entry := range entries {
+		key, err := hex.DecodeString(entry.Key)
+		if err != nil {
+			t.Fatalf("Invalid fixture key %v: %v", entry.Key, err)
+		}
+		value, err := hex.DecodeString(entry.Value)
+		if err != nil {
+			t.Fatalf("Invalid fixture value %v: %v", entry.Value, err)
+		}
+		store.Set(key, value)
+	}
+}
+
+func TestGetConsensusVersion(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+
+	// A store without version has the initial version
+	if poaKeeper.GetConsensusVersion(ctx) != 1 {
+		t.Errorf("GetConsensusVersion should return 1 for an unversioned store, got %v", poaKeeper.GetConsensusVersion(ctx))
+	}
+
+	poaKeeper.SetConsensusVersion(ctx, 5)
+	if poaKeeper.GetConsensusVersion(ctx) != 5 {
+		t.Errorf("GetConsensusVersion should return 5, got %v", poaKeeper.GetConsensusVersion(ctx))
+	}
+}
+
+func TestMigrateV1Store(t *testing.T) {
+	ctx, poaKeeper, storeKey, paramspace := poa.MockContextWithStoreKey()
+	loadStoreFixture(t, ctx, storeKey, "testdata/v1_store.json")
+
+	// A v1 store only has the validator set params
+	paramspace.Set(ctx, types.KeyMaxValidators, types.DefaultMaxValidators)
+	paramspace.Set(ctx, types.KeyQuorum, types.DefaultQuorum)
+
+	err := keeper.NewMigrator(poaKeeper).Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Migrate should migrate a v1 store, got error %v", err)
+	}
+	if poaKeeper.GetConsensusVersion(ctx) != types.ConsensusVersion {
+		t.Errorf("Migrate should set the consensus version to %v, got %v", types.ConsensusVersion, poaKeeper.GetConsensusVersion(ctx))
+	}
+
+	// The migrated store must be consistent
+	msg, broken := keeper.AllInvariants(poaKeeper)(ctx)
+	if broken {
+		t.Errorf("Migrate should leave a consistent store: %v", msg)
+	}
+
+	// Validators and their states
+	validators := poaKeeper.GetAllValidators(ctx)
+	if len(validators) != 3 {
+		t.Fatalf("Migrate should keep 3 validators, found %v", len(validators))
+	}
+	expectedStates := map[string]uint16{
+		"alice": types.ValidatorStateJoined,
+		"bob":   types.ValidatorStateJoined,
+		"carol": types.ValidatorStateLeaving,
+	}
+	for _, validator := range validators {
+		state, found := poaKeeper.GetValidatorState(ctx, validator.GetOperator())
+		if !found {
+			t.Errorf("Migrate should keep the state of %v", validator.GetDescription().Moniker)
+		}
+		if state != expectedStates[validator.GetDescription().Moniker] {
+			t.Errorf("Migrate should keep the state %v for %v, got %v", expectedStates[validator.GetDescription().Moniker], validator.GetDescription().Moniker, state)
+		}
+		_, found = poaKeeper.GetValidatorByConsAddr(ctx, validator.GetConsAddr())
+		if !found {
+			t.Errorf("Migrate should keep the consensus address index of %v", validator.GetDescription().Moniker)
+		}
+	}
+
+	// The validators are counted
+	if poaKeeper.GetValidatorCount(ctx) != 3 {
+		t.Errorf("Migrate should count 3 validators, got %v", poaKeeper.GetValidatorCount(ctx))
+	}
+	if poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateJoined) != 2 || poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateLeaving) != 1 {
+		t.Errorf("Migrate should count 2 joined and 1 leaving validators, got %v and %v", poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateJoined), poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateLeaving))
+	}
+
+	// Application of dave, approved by alice and rejected by bob
+	applications := poaKeeper.GetAllApplications(ctx)
+	if len(applications) != 1 {
+		t.Fatalf("Migrate should keep 1 application, found %v", len(applications))
+	}
+	if applications[0].GetSubject().GetDescription().Moniker != "dave" {
+		t.Errorf("Migrate should keep the application of dave, found %v", applications[0].GetSubject().GetDescription().Moniker)
+	}
+	if applications[0].GetApprovals() != 1 || applications[0].GetTotal() != 2 {
+		t.Errorf("Migrate should keep 1 approval out of 2 votes for the application, found %v out of %v", applications[0].GetApprovals(), applications[0].GetTotal())
+	}
+
+	// Kick proposal against bob, approved by alice
+	kickProposals := poaKeeper.GetAllKickProposals(ctx)
+	if len(kickProposals) != 1 {
+		t.Fatalf("Migrate should keep 1 kick proposal, found %v", len(kickProposals))
+	}
+	if kickProposals[0].GetSubject().GetDescription().Moniker != "bob" {
+		t.Errorf("Migrate should keep the kick proposal against bob, found %v", kickProposals[0].GetSubject().GetDescription().Moniker)
+	}
+	if kickProposals[0].GetApprovals() != 1 || kickProposals[0].GetTotal() != 1 {
+		t.Errorf("Migrate should keep 1 approval out of 1 vote for the kick proposal, found %v out of %v", kickProposals[0].GetApprovals(), kickProposals[0].GetTotal())
+	}
+
+	// The choices of the voters are stored under their own keys
+	addresses := make(map[string]sdk.ValAddress)
+	for _, validator := range validators {
+		addresses[validator.GetDescription().Moniker] = validator.GetOperator()
+	}
+	daveAddr := applications[0].GetSubject().GetOperator()
+	// The approvers were not recorded, the choices of a split vote are unknown
+	choice, found := poaKeeper.GetVoterChoice(ctx, types.VoteTypeApplication, daveAddr, addresses["alice"])
+	if !found || !choice.Unknown {
+		t.Errorf("Migrate should store an unknown choice of alice for the application of dave")
+	}
+	choice, found = poaKeeper.GetVoterChoice(ctx, types.VoteTypeApplication, daveAddr, addresses["bob"])
+	if !found || !choice.Unknown {
+		t.Errorf("Migrate should store an unknown choice of bob for the application of dave")
+	}
+	choice, found = poaKeeper.GetVoterChoice(ctx, types.VoteTypeKickProposal, addresses["bob"], addresses["alice"])
+	if !found || !choice.Approve || choice.Unknown {
+		t.Errorf("Migrate should store the approval of alice for the kick proposal against bob")
+	}
+	if poaKeeper.HasVoted(ctx, types.VoteTypeKickProposal, addresses["bob"], addresses["carol"]) {
+		t.Errorf("Migrate should not store a vote of carol for the kick proposal against bob")
+	}
+	if msg, broken := keeper.VoteTotalsInvariant(poaKeeper)(ctx); broken {
+		t.Errorf("Migrate should keep the tally counters consistent with the unknown choices: %v", msg)
+	}
+
+	// The distribution params are set to their default values
+	if !poaKeeper.BlockReward(ctx).IsEqual(types.DefaultBlockReward) {
+		t.Errorf("Migrate should set the default block reward, got %v", poaKeeper.BlockReward(ctx))
+	}
+	if !poaKeeper.ProposerBonus(ctx).Equal(types.DefaultProposerBonus) {
+		t.Errorf("Migrate should set the default proposer bonus, got %v", poaKeeper.ProposerBonus(ctx))
+	}
+	if poaKeeper.MaxMissedVotes(ctx) != types.DefaultMaxMissedVotes {
+		t.Errorf("Migrate should set the default max missed votes, got %v", poaKeeper.MaxMissedVotes(ctx))
+	}
+	if poaKeeper.MaxValidators(ctx) != types.DefaultMaxValidators {
+		t.Errorf("Migrate should keep the max validators, got %v", poaKeeper.MaxValidators(ctx))
+	}
+}
+
+// Layout of a vote in a v3 store
+type v3Vote struct {
+	Subject   types.Validator
+	Approvals uint64
+	Total     uint64
+	Voters    []sdk.ValAddress
+	Approvers []sdk.ValAddress
+}
+
+func TestMigrateV3Votes(t *testing.T) {
+	ctx, poaKeeper, storeKey, _ := poa.MockContextWithStoreKey()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	poaKeeper.SetConsensusVersion(ctx, 3)
+	candidate, _ := poa.MockValidator()
+	voter1 := poa.MockValAddress()
+	voter2 := poa.MockValAddress()
+
+	// The first voter rejected and the second approved the kick proposal
+	legacy := v3Vote{
+		Subject:   candidate,
+		Approvals: 1,
+		Total:     2,
+		Voters:    []sdk.ValAddress{voter1, voter2},
+		Approvers: []sdk.ValAddress{voter2},
+	}
+	ctx.KVStore(storeKey).Set(types.GetKickProposalKey(candidate.GetOperator()), types.ModuleCdc.MustMarshalBinaryBare(&legacy))
+
+	err := keeper.NewMigrator(poaKeeper).Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Migrate should migrate a v3 store, got error %v", err)
+	}
+
+	kickProposal, found := poaKeeper.GetKickProposal(ctx, candidate.GetOperator())
+	if !found {
+		t.Fatalf("Migrate should keep the kick proposal")
+	}
+	if kickProposal.GetApprovals() != 1 || kickProposal.GetTotal() != 2 {
+		t.Errorf("Migrate should keep 1 approval out of 2 votes, found %v out of %v", kickProposal.GetApprovals(), kickProposal.GetTotal())
+	}
+	choice, found := poaKeeper.GetVoterChoice(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), voter1)
+	if !found || choice.Approve {
+		t.Errorf("Migrate should store the rejection of the first voter")
+	}
+	choice, found = poaKeeper.GetVoterChoice(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), voter2)
+	if !found || !choice.Approve {
+		t.Errorf("Migrate should store the approval of the second voter")
+	}
+	if msg, broken := keeper.VoteTotalsInvariant(poaKeeper)(ctx); broken {
+		t.Errorf("Migrate should keep the tally counters consistent with the choices: %v", msg)
+	}
+}
+
+func TestMigrateV5OutOfSetMarkers(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	poaKeeper.SetConsensusVersion(ctx, 5)
+
+	// A jailed validator without the out-of-set marker and a joined validator
+	jailed, _ := poa.MockValidator()
+	joined, _ := poa.MockValidator()
+	poaKeeper.SetValidator(ctx, jailed)
+	poaKeeper.SetValidatorState(ctx, jailed, types.ValidatorStateJailed)
+	poaKeeper.SetValidator(ctx, joined)
+	poaKeeper.SetValidatorState(ctx, joined, types.ValidatorStateJoined)
+
+	err := keeper.NewMigrator(poaKeeper).Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Migrate should migrate a v5 store, got error %v", err)
+	}
+
+	if !poaKeeper.IsValidatorOutOfSet(ctx, jailed.GetOperator()) {
+		t.Errorf("Migrate should mark the jailed validator as out of the set")
+	}
+	if poaKeeper.IsValidatorOutOfSet(ctx, joined.GetOperator()) {
+		t.Errorf("Migrate should not mark the joined validator as out of the set")
+	}
+}
+
+func TestMigrateV6StateHeights(t *testing.T) {
+	ctx, poaKeeper, storeKey, _ := poa.MockContextWithStoreKey()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	poaKeeper.SetConsensusVersion(ctx, 6)
+
+	// A validator jailed before the state heights were recorded and a validator jailed at height 10
+	legacy, _ := poa.MockValidator()
+	recent, _ := poa.MockValidator()
+	poaKeeper.SetValidator(ctx, legacy)
+	poaKeeper.SetValidatorState(ctx, legacy, types.ValidatorStateJailed)
+	poaKeeper.SetValidatorOutOfSet(ctx, legacy.GetOperator())
+	ctx.KVStore(storeKey).Delete(types.GetValidatorStateHeightKey(legacy.GetOperator()))
+	poaKeeper.SetValidator(ctx, recent)
+	poaKeeper.SetValidatorState(ctx.WithBlockHeight(10), recent, types.ValidatorStateJailed)
+	poaKeeper.SetValidatorOutOfSet(ctx, recent.GetOperator())
+
+	err := keeper.NewMigrator(poaKeeper).Migrate(ctx.WithBlockHeight(100))
+	if err != nil {
+		t.Fatalf("Migrate should migrate a v6 store, got error %v", err)
+	}
+
+	if poaKeeper.GetValidatorStateHeight(ctx, legacy.GetOperator()) != 0 {
+		t.Errorf("Migrate should leave the unknown state height unset, got %v", poaKeeper.GetValidatorStateHeight(ctx, legacy.GetOperator()))
+	}
+	if poaKeeper.GetValidatorStateHeight(ctx, recent.GetOperator()) != 10 {
+		t.Errorf("Migrate should keep the recorded state height, got %v", poaKeeper.GetValidatorStateHeight(ctx, recent.GetOperator()))
+	}
+}
+
+func TestMigrateV8JoinHeights(t *testing.T) {
+	ctx, poaKeeper, storeKey, _ := poa.MockContextWithStoreKey()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	poaKeeper.SetConsensusVersion(ctx, 8)
+
+	// A validator appended at height 10 before the join heights were recorded, a genesis validator and a validator joined at height 20
+	legacy, _ := poa.MockValidator()
+	genesis, _ := poa.MockValidator()
+	recent, _ := poa.MockValidator()
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(10), legacy)
+	poaKeeper.SetValidatorState(ctx.WithBlockHeight(11), legacy, types.ValidatorStateJoined)
+	ctx.KVStore(storeKey).Delete(types.GetValidatorJoinHeightKey(legacy.GetOperator()))
+	poaKeeper.SetValidator(ctx, genesis)
+	poaKeeper.SetValidatorState(ctx, genesis, types.ValidatorStateJoined)
+	poaKeeper.AppendValidator(ctx.WithBlockHeight(20), recent)
+	poaKeeper.SetValidatorState(ctx.WithBlockHeight(21), recent, types.ValidatorStateJoined)
+
+	err := keeper.NewMigrator(poaKeeper).Migrate(ctx.WithBlockHeight(100))
+	if err != nil {
+		t.Fatalf("Migrate should migrate a v8 store, got error %v", err)
+	}
+
+	if poaKeeper.GetValidatorJoinHeight(ctx, legacy.GetOperator()) != 0 {
+		t.Errorf("Migrate should leave the unknown join height unset, got %v", poaKeeper.GetValidatorJoinHeight(ctx, legacy.GetOperator()))
+	}
+	if poaKeeper.GetValidatorJoinHeight(ctx, genesis.GetOperator()) != 0 {
+		t.Errorf("Migrate should not set a join height to a genesis validator, got %v", poaKeeper.GetValidatorJoinHeight(ctx, genesis.GetOperator()))
+	}
+	if poaKeeper.GetValidatorJoinHeight(ctx, recent.GetOperator()) != 20 {
+		t.Errorf("Migrate should keep the recorded join height, got %v", poaKeeper.GetValidatorJoinHeight(ctx, recent.GetOperator()))
+	}
+}
+
+func TestMigrateNewerStore(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	poaKeeper.SetConsensusVersion(ctx, types.ConsensusVersion+1)
+
+	// A store more recent than the module can't be migrated
+	err := keeper.NewMigrator(poaKeeper).Migrate(ctx)
+	if err == nil {
+		t.Errorf("Migrate should fail if the store is newer than the module")
+	}
+}
@@ -17,6 +17,24 @@ func (k Keeper) Quorum(ctx sdk.Context) (res uint16) {
 	return
 }
 
+// BlockReward - Coins minted for the validators on each block
+func (k Keeper) BlockReward(ctx sdk.Context) (res sdk.Coins) {
+	k.paramspace.Get(ctx, types.KeyBlockReward, &res)
+	return
+}
+
+// ProposerBonus - Fraction of the rewards given to the block proposer
+func (k Keeper) ProposerBonus(ctx sdk.Context) (res sdk.Dec) {
+	k.paramspace.Get(ctx, types.KeyProposerBonus, &res)
+	return
+}
+
+// MaxMissedVotes - Max number of consecutive missed votes before a validator is automatically proposed to be kicked
+func (k Keeper) MaxMissedVotes(ctx sdk.Context) (res uint64) {
+	k.paramspace.Get(ctx, types.KeyMaxMissedVotes, &res)
+	return
+}
+
 // GetParams returns the total set of poa parameters.
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 	k.paramspace.GetParamSet(ctx, &params)
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ltacker/poa/types"
+)
+
+// Get the participation of a validator in the votes
+func (k Keeper) GetParticipation(ctx sdk.Context, addr sdk.ValAddress) types.Participation {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.GetParticipationKey(addr))
+	if value == nil {
+		return types.Participation{}
+	}
+
+	return types.MustUnmarshalParticipation(k.cdc, value)
+}
+
+// Set the participation of a validator in the votes
+func (k Keeper) SetParticipation(ctx sdk.Context, addr sdk.ValAddress, participation types.Participation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetParticipationKey(addr), types.MustMarshalParticipation(k.cdc, participation))
+}
+
+// Record the vote of a validator on an application or a kick proposal
+func (k Keeper) RecordVote(ctx sdk.Context, voter sdk.ValAddress, voteType uint16) {
+	participation := k.GetParticipation(ctx, voter)
+
+	switch voteType {
+	case types.VoteTypeApplication:
+		participation.ApplicationVotes++
+	case types.VoteTypeKickProposal:
+		participation.KickProposalVotes++
+	}
+	participation.ConsecutiveMissedVotes = 0
+	participation.LastVoteHeight = ctx.BlockHeight()
+
+	k.SetParticipation(ctx, voter, participation)
+}
+
+// Record a missed vote for the validators eligible to vote on a closing proposal who didn't vote
+// A vote is only missed on a proposal that expired without decision, a proposal decided by the quorum or closed early
+// didn't need the vote of the other validators
+// All the validators except the subject of the proposal that joined before the proposal has been opened are eligible,
+// a validator that joined during the vote didn't have the whole vote to act
+func (k Keeper) RecordMissedVotes(ctx sdk.Context, proposalType uint16, vote types.Vote, outcome string) {
+	if outcome != types.ProposalOutcomeExpired {
+		return
+	}
+
+	candidateAddr := vote.GetSubject().GetOperator()
+	openHeight := k.GetProposalOpenHeight(ctx, proposalType, candidateAddr)
+	voted := make(map[string]bool)
+	k.IterateVoterChoices(ctx, proposalType, candidateAddr, func(choice types.VoterChoice) bool {
+		voted[choice.Voter.String()] = true
+		return false
+	})
+
+	for _, validator := range k.GetAllValidators(ctx) {
+		addr := validator.GetOperator()
+		if addr.Equals(candidateAddr) || voted[addr.String()] || k.GetValidatorJoinHeight(ctx, addr) >= openHeight {
+			continue
+		}
+
+		participation := k.GetParticipation(ctx, addr)
+		participation.MissedVotes++
+		participation.ConsecutiveMissedVotes++
+		k.SetParticipation(ctx, addr, participation)
+	}
+}
@@ -1,8 +1,11 @@
 package keeper
 
 import (
+	"sort"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -14,19 +17,43 @@ func NewQuerier(k Keeper) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
 		switch path[0] {
 		case types.QueryValidators:
-			return queryValidators(ctx, k)
+			return queryValidators(ctx, req, k)
 
 		case types.QueryValidator:
 			return queryValidator(ctx, req, k)
 
+		case types.QueryValidatorByConsAddr:
+			return queryValidatorByConsAddr(ctx, req, k)
+
+		case types.QueryApplication:
+			return queryApplication(ctx, req, k)
+
+		case types.QueryApplicationByConsAddr:
+			return queryApplicationByConsAddr(ctx, req, k)
+
 		case types.QueryParams:
 			return queryParams(ctx, k)
 
 		case types.QueryApplications:
-			return queryApplications(ctx, k)
+			return queryApplications(ctx, req, k)
 
 		case types.QueryKickProposals:
-			return queryKickProposals(ctx, k)
+			return queryKickProposals(ctx, req, k)
+
+		case types.QueryKickProposal:
+			return queryKickProposal(ctx, req, k)
+
+		case types.QueryRewards:
+			return queryRewards(ctx, req, k)
+
+		case types.QueryWithdrawAddress:
+			return queryWithdrawAddress(ctx, req, k)
+
+		case types.QueryProposalHistory:
+			return queryProposalHistory(ctx, req, k)
+
+		case types.QueryParticipation:
+			return queryParticipation(ctx, req, k)
 
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown poa query endpoint")
@@ -34,11 +61,105 @@ func NewQuerier(k Keeper) sdk.Querier {
 	}
 }
 
-func queryValidators(ctx sdk.Context, k Keeper) ([]byte, error) {
+// Get the params of a list query, a query without params returns all the results
+func unmarshalListParams(req abci.RequestQuery) (types.QueryListParams, error) {
+	params := types.NewQueryListParams(1, 0, "", "")
+	if len(req.Data) == 0 {
+		return params, nil
+	}
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return params, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	if params.Page < 0 || params.Limit < 0 {
+		return params, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "page and limit must be positive")
+	}
+	if params.Page == 0 {
+		params.Page = 1
+	}
+
+	return params, nil
+}
+
+// Get the bounds of the requested page in a list of count elements
+func paginate(count int, params types.QueryListParams) (start, end int) {
+	start, end = client.Paginate(count, params.Page, params.Limit, count)
+	if start < 0 || end < 0 {
+		return 0, 0
+	}
+	return start, end
+}
+
+// Filter, sort and paginate the votes of a list query
+func listVotes(votes []types.Vote, params types.QueryListParams) ([]types.Vote, error) {
+	if params.Status != "" {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "the status filter only applies to the validators")
+	}
+
+	switch params.SortBy {
+	case "", types.SortByOperator:
+		// The votes are stored by operator address
+	case types.SortByMoniker:
+		sort.SliceStable(votes, func(i, j int) bool {
+			return votes[i].GetSubject().GetDescription().Moniker < votes[j].GetSubject().GetDescription().Moniker
+		})
+	case types.SortByApprovals:
+		sort.SliceStable(votes, func(i, j int) bool {
+			return votes[i].GetApprovals() > votes[j].GetApprovals()
+		})
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unknown sort order: %s", params.SortBy)
+	}
+
+	start, end := paginate(len(votes), params)
+	return votes[start:end], nil
+}
+
+func queryValidators(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	params, err := unmarshalListParams(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get all the validators
 	validators := k.GetAllValidators(ctx)
 
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, validators)
+	// Filter the validators by state
+	if params.Status != "" {
+		state, err := types.ValidatorStateFromString(params.Status)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+		}
+
+		var filtered []types.Validator
+		for _, validator := range validators {
+			validatorState, found := k.GetValidatorState(ctx, validator.GetOperator())
+			if found && validatorState == state {
+				filtered = append(filtered, validator)
+			}
+		}
+		validators = filtered
+	}
+
+	switch params.SortBy {
+	case "", types.SortByOperator:
+		// The validators are stored by operator address
+	case types.SortByMoniker:
+		sort.SliceStable(validators, func(i, j int) bool {
+			return validators[i].GetDescription().Moniker < validators[j].GetDescription().Moniker
+		})
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unknown sort order for validators: %s", params.SortBy)
+	}
+
+	start, end := paginate(len(validators), params)
+	validatorsWithState := []types.ValidatorWithState{}
+	for _, validator := range validators[start:end] {
+		validatorsWithState = append(validatorsWithState, validatorWithState(ctx, k, validator))
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, validatorsWithState)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
@@ -60,7 +181,28 @@ func queryValidator(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, e
 		return nil, types.ErrNoValidatorFound
 	}
 
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, validator)
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, validatorWithState(ctx, k, validator))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryValidatorByConsAddr(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryConsAddrParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	validator, found := k.GetValidatorByConsAddr(ctx, params.ConsAddr)
+	if !found {
+		return nil, types.ErrNoValidatorFound
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, validatorWithState(ctx, k, validator))
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
@@ -68,6 +210,13 @@ func queryValidator(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, e
 	return res, nil
 }
 
+// Get a validator with its state and the height it entered the state
+func validatorWithState(ctx sdk.Context, k Keeper, validator types.Validator) types.ValidatorWithState {
+	state, _ := k.GetValidatorState(ctx, validator.GetOperator())
+	stateHeight := k.GetValidatorStateHeight(ctx, validator.GetOperator())
+	return types.NewValidatorWithState(validator, state, stateHeight)
+}
+
 func queryParams(ctx sdk.Context, k Keeper) ([]byte, error) {
 	params := k.GetParams(ctx)
 
@@ -79,9 +228,17 @@ func queryParams(ctx sdk.Context, k Keeper) ([]byte, error) {
 	return res, nil
 }
 
-func queryApplications(ctx sdk.Context, k Keeper) ([]byte, error) {
+func queryApplications(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	params, err := unmarshalListParams(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get all the applications
-	applications := k.GetAllApplications(ctx)
+	applications, err := listVotes(k.GetAllApplications(ctx), params)
+	if err != nil {
+		return nil, err
+	}
 
 	res, err := codec.MarshalJSONIndent(types.ModuleCdc, applications)
 	if err != nil {
@@ -91,9 +248,96 @@ func queryApplications(ctx sdk.Context, k Keeper) ([]byte, error) {
 	return res, nil
 }
 
-func queryKickProposals(ctx sdk.Context, k Keeper) ([]byte, error) {
+func queryApplication(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryValidatorParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	application, found := k.GetApplication(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoApplicationFound
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, voteTally(ctx, k, types.VoteTypeApplication, application, nil))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryApplicationByConsAddr(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryConsAddrParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	application, found := k.GetApplicationByConsAddr(ctx, params.ConsAddr)
+	if !found {
+		return nil, types.ErrNoApplicationFound
+	}
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, voteTally(ctx, k, types.VoteTypeApplication, application, nil))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryKickProposal(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryValidatorParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	kickProposal, found := k.GetKickProposal(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoKickProposalFound
+	}
+
+	// The candidate of the kick proposal cannot vote
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, voteTally(ctx, k, types.VoteTypeKickProposal, kickProposal, params.ValidatorAddr))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+// Get the tally of a vote where all the validators except the excluded one can vote
+// The voter pool is the same as the one used to check the quorum when a vote is performed
+func voteTally(ctx sdk.Context, k Keeper, voteType uint16, vote types.Vote, excluded sdk.ValAddress) types.Tally {
+	var voterPool []sdk.ValAddress
+	for _, validator := range k.GetAllValidators(ctx) {
+		if !validator.GetOperator().Equals(excluded) {
+			voterPool = append(voterPool, validator.GetOperator())
+		}
+	}
+
+	votes := k.GetAllVoterChoices(ctx, voteType, vote.GetSubject().GetOperator())
+
+	return vote.Tally(voterPool, votes, uint64(k.Quorum(ctx)))
+}
+
+func queryKickProposals(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	params, err := unmarshalListParams(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get all the kick proposals
-	kickProposals := k.GetAllKickProposals(ctx)
+	kickProposals, err := listVotes(k.GetAllKickProposals(ctx), params)
+	if err != nil {
+		return nil, err
+	}
 
 	res, err := codec.MarshalJSONIndent(types.ModuleCdc, kickProposals)
 	if err != nil {
@@ -102,3 +346,113 @@ func queryKickProposals(ctx sdk.Context, k Keeper) ([]byte, error) {
 
 	return res, nil
 }
+
+func queryRewards(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryValidatorParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	_, found := k.GetValidator(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoValidatorFound
+	}
+
+	rewards := k.GetValidatorRewards(ctx, params.ValidatorAddr)
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, rewards)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryWithdrawAddress(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryValidatorParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	_, found := k.GetValidator(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoValidatorFound
+	}
+
+	withdrawAddr := k.GetWithdrawAddress(ctx, params.ValidatorAddr)
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, withdrawAddr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryParticipation(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryValidatorParams
+
+	err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	_, found := k.GetValidator(ctx, params.ValidatorAddr)
+	if !found {
+		return nil, types.ErrNoValidatorFound
+	}
+
+	participation := k.GetParticipation(ctx, params.ValidatorAddr)
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, participation)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryProposalHistory(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	params := types.NewQueryProposalHistoryParams(1, 0, nil, "")
+	if len(req.Data) > 0 {
+		err := types.ModuleCdc.UnmarshalJSON(req.Data, &params)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+		}
+	}
+	if params.Page < 0 || params.Limit < 0 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "page and limit must be positive")
+	}
+	if params.Page == 0 {
+		params.Page = 1
+	}
+	if params.Outcome != "" && !types.IsValidProposalOutcome(params.Outcome) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unknown proposal outcome: %s", params.Outcome)
+	}
+
+	// Filter the closed proposals by candidate and outcome
+	closedProposals := []types.ClosedProposal{}
+	for _, closedProposal := range k.GetAllClosedProposals(ctx) {
+		if !params.Candidate.Empty() && !closedProposal.Subject.GetOperator().Equals(params.Candidate) {
+			continue
+		}
+		if params.Outcome != "" && closedProposal.Outcome != params.Outcome {
+			continue
+		}
+		closedProposals = append(closedProposals, closedProposal)
+	}
+
+	start, end := paginate(len(closedProposals), types.NewQueryListParams(params.Page, params.Limit, "", ""))
+	closedProposals = closedProposals[start:end]
+
+	res, err := codec.MarshalJSONIndent(types.ModuleCdc, closedProposals)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
@@ -0,0 +1,317 @@
+package keeper_test
+
+import (
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/types"
+)
+
+func TestQueryValidatorByConsAddr(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	querier := keeper.NewQuerier(poaKeeper)
+	validator, _ := poa.MockValidator()
+	notValidator, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, validator)
+
+	// The validator is found by consensus address
+	req := abci.RequestQuery{
+		Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryConsAddrParams(validator.GetConsAddr())),
+	}
+	res, err := querier(ctx, []string{types.QueryValidatorByConsAddr}, req)
+	if err != nil {
+		t.Fatalf("QueryValidatorByConsAddr should find the validator, got error %v", err)
+	}
+	var retrieved types.ValidatorWithState
+	types.ModuleCdc.MustUnmarshalJSON(res, &retrieved)
+	if !retrieved.Validator.GetOperator().Equals(validator.GetOperator()) {
+		t.Errorf("QueryValidatorByConsAddr should return %v, got %v", validator.GetOperator(), retrieved.Validator.GetOperator())
+	}
+
+	// No validator with this consensus address
+	req.Data = types.ModuleCdc.MustMarshalJSON(types.NewQueryConsAddrParams(notValidator.GetConsAddr()))
+	_, err = querier(ctx, []string{types.QueryValidatorByConsAddr}, req)
+	if err == nil || err.Error() != types.ErrNoValidatorFound.Error() {
+		t.Errorf("QueryValidatorByConsAddr with no validator, error should be %v, got %v", types.ErrNoValidatorFound, err)
+	}
+}
+
+func TestQueryValidatorState(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	querier := keeper.NewQuerier(poaKeeper)
+	validator, _ := poa.MockValidator()
+
+	ctx = ctx.WithBlockHeight(5)
+	poaKeeper.AppendValidator(ctx, validator)
+	ctx = ctx.WithBlockHeight(10)
+	poaKeeper.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+
+	// Setting the same state keeps the height the state was entered
+	ctx = ctx.WithBlockHeight(15)
+	poaKeeper.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+
+	req := abci.RequestQuery{
+		Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryValidatorParams(validator.GetOperator())),
+	}
+	res, err := querier(ctx, []string{types.QueryValidator}, req)
+	if err != nil {
+		t.Fatalf("QueryValidator should find the validator, got error %v", err)
+	}
+	var retrieved types.ValidatorWithState
+	types.ModuleCdc.MustUnmarshalJSON(res, &retrieved)
+	if !retrieved.Validator.GetOperator().Equals(validator.GetOperator()) {
+		t.Errorf("QueryValidator should return %v, got %v", validator.GetOperator(), retrieved.Validator.GetOperator())
+	}
+	if retrieved.State != "joined" {
+		t.Errorf("QueryValidator should return the joined state, got %v", retrieved.State)
+	}
+	if retrieved.StateHeight != 10 {
+		t.Errorf("QueryValidator should return the height the state was entered 10, got %v", retrieved.StateHeight)
+	}
+}
+
+func TestQueryValidatorsList(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	querier := keeper.NewQuerier(poaKeeper)
+	monikers := []string{"carol", "alice", "bob"}
+	for _, moniker := range monikers {
+		validator, _ := poa.MockValidator()
+		validator.Description.Moniker = moniker
+		poaKeeper.AppendValidator(ctx, validator)
+		if moniker != "bob" {
+			poaKeeper.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+		}
+	}
+
+	queryValidators := func(params interface{}) ([]types.ValidatorWithState, error) {
+		var req abci.RequestQuery
+		if params != nil {
+			req.Data = types.ModuleCdc.MustMarshalJSON(params)
+		}
+		res, err := querier(ctx, []string{types.QueryValidators}, req)
+		if err != nil {
+			return nil, err
+		}
+		var validators []types.ValidatorWithState
+		types.ModuleCdc.MustUnmarshalJSON(res, &validators)
+		return validators, nil
+	}
+
+	// A query without params returns all the validators
+	validators, err := queryValidators(nil)
+	if err != nil || len(validators) != 3 {
+		t.Errorf("QueryValidators without params should return 3 validators, got %v, error %v", len(validators), err)
+	}
+
+	// Filter by state
+	validators, err = queryValidators(types.NewQueryListParams(1, 0, "joined", ""))
+	if err != nil || len(validators) != 2 {
+		t.Errorf("QueryValidators with joined status should return 2 validators, got %v, error %v", len(validators), err)
+	}
+
+	// Sort by moniker and paginate
+	validators, err = queryValidators(types.NewQueryListParams(2, 1, "", types.SortByMoniker))
+	if err != nil || len(validators) != 1 {
+		t.Fatalf("QueryValidators page 2 with limit 1 should return 1 validator, got %v, error %v", len(validators), err)
+	}
+	if validators[0].Validator.GetDescription().Moniker != "bob" {
+		t.Errorf("QueryValidators sorted by moniker page 2 should return bob, got %v", validators[0].Validator.GetDescription().Moniker)
+	}
+	if validators[0].State != "joining" {
+		t.Errorf("QueryValidators should return the state of bob as joining, got %v", validators[0].State)
+	}
+
+	// Out of bounds page
+	validators, err = queryValidators(types.NewQueryListParams(4, 1, "", ""))
+	if err != nil || len(validators) != 0 {
+		t.Errorf("QueryValidators out of bounds should return no validator, got %v, error %v", len(validators), err)
+	}
+
+	// Invalid params
+	_, err = queryValidators(types.NewQueryListParams(1, 0, "unknown", ""))
+	if err == nil {
+		t.Errorf("QueryValidators with an unknown status should fail")
+	}
+	_, err = queryValidators(types.NewQueryListParams(1, 0, "", types.SortByApprovals))
+	if err == nil {
+		t.Errorf("QueryValidators sorted by approvals should fail")
+	}
+}
+
+func TestQueryApplicationsList(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	querier := keeper.NewQuerier(poaKeeper)
+	voter := poa.MockValAddress()
+	candidate1, _ := poa.MockValidator()
+	candidate2, _ := poa.MockValidator()
+
+	poaKeeper.AppendApplication(ctx, candidate1)
+	application := types.NewVote(candidate2)
+	poaKeeper.AddVote(ctx, types.VoteTypeApplication, &application, voter, true)
+	poaKeeper.SetApplication(ctx, application)
+	poaKeeper.SetApplicationByConsAddr(ctx, application)
+
+	// The most approved application first
+	req := abci.RequestQuery{
+		Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryListParams(1, 1, "", types.SortByApprovals)),
+	}
+	res, err := querier(ctx, []string{types.QueryApplications}, req)
+	if err != nil {
+		t.Fatalf("QueryApplications should return the applications, got error %v", err)
+	}
+	var applications []types.Vote
+	types.ModuleCdc.MustUnmarshalJSON(res, &applications)
+	if len(applications) != 1 || !applications[0].GetSubject().GetOperator().Equals(candidate2.GetOperator()) {
+		t.Errorf("QueryApplications sorted by approvals should return the application of %v first", candidate2.GetOperator())
+	}
+
+	// The status filter only applies to validators
+	req.Data = types.ModuleCdc.MustMarshalJSON(types.NewQueryListParams(1, 0, "joined", ""))
+	_, err = querier(ctx, []string{types.QueryApplications}, req)
+	if err == nil {
+		t.Errorf("QueryApplications with a status filter should fail")
+	}
+}
+
+func TestQueryApplication(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	querier := keeper.NewQuerier(poaKeeper)
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	candidate, _ := poa.MockValidator()
+	notCandidate, _ := poa.MockValidator()
+
+	poaKeeper.AppendApplication(ctx, candidate)
+
+	// The application is found by operator address
+	req := abci.RequestQuery{
+		Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryValidatorParams(candidate.GetOperator())),
+	}
+	res, err := querier(ctx, []string{types.QueryApplication}, req)
+	if err != nil {
+		t.Fatalf("QueryApplication should find the application, got error %v", err)
+	}
+	var retrieved types.Tally
+	types.ModuleCdc.MustUnmarshalJSON(res, &retrieved)
+	if !retrieved.Vote.GetSubject().GetOperator().Equals(candidate.GetOperator()) {
+		t.Errorf("QueryApplication should return %v, got %v", candidate.GetOperator(), retrieved.Vote.GetSubject().GetOperator())
+	}
+
+	// The application is found by consensus address
+	req.Data = types.ModuleCdc.MustMarshalJSON(types.NewQueryConsAddrParams(candidate.GetConsAddr()))
+	res, err = querier(ctx, []string{types.QueryApplicationByConsAddr}, req)
+	if err != nil {
+		t.Fatalf("QueryApplicationByConsAddr should find the application, got error %v", err)
+	}
+	types.ModuleCdc.MustUnmarshalJSON(res, &retrieved)
+	if !retrieved.Vote.GetSubject().GetOperator().Equals(candidate.GetOperator()) {
+		t.Errorf("QueryApplicationByConsAddr should return %v, got %v", candidate.GetOperator(), retrieved.Vote.GetSubject().GetOperator())
+	}
+
+	// No application for this candidate
+	req.Data = types.ModuleCdc.MustMarshalJSON(types.NewQueryValidatorParams(notCandidate.GetOperator()))
+	_, err = querier(ctx, []string{types.QueryApplication}, req)
+	if err == nil || err.Error() != types.ErrNoApplicationFound.Error() {
+		t.Errorf("QueryApplication with no application, error should be %v, got %v", types.ErrNoApplicationFound, err)
+	}
+	req.Data = types.ModuleCdc.MustMarshalJSON(types.NewQueryConsAddrParams(notCandidate.GetConsAddr()))
+	_, err = querier(ctx, []string{types.QueryApplicationByConsAddr}, req)
+	if err == nil || err.Error() != types.ErrNoApplicationFound.Error() {
+		t.Errorf("QueryApplicationByConsAddr with no application, error should be %v, got %v", types.ErrNoApplicationFound, err)
+	}
+}
+
+func TestQueryKickProposal(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	querier := keeper.NewQuerier(poaKeeper)
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	voter1, _ := poa.MockValidator()
+	voter2, _ := poa.MockValidator()
+	candidate, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, voter1)
+	poaKeeper.AppendValidator(ctx, voter2)
+	poaKeeper.AppendValidator(ctx, candidate)
+	kickProposal := types.NewVote(candidate)
+	poaKeeper.AddVote(ctx, types.VoteTypeKickProposal, &kickProposal, voter1.GetOperator(), true)
+	poaKeeper.SetKickProposal(ctx, kickProposal)
+
+	req := abci.RequestQuery{
+		Data: types.ModuleCdc.MustMarshalJSON(types.NewQueryValidatorParams(candidate.GetOperator())),
+	}
+	res, err := querier(ctx, []string{types.QueryKickProposal}, req)
+	if err != nil {
+		t.Fatalf("QueryKickProposal should find the kick proposal, got error %v", err)
+	}
+	var tally types.Tally
+	types.ModuleCdc.MustUnmarshalJSON(res, &tally)
+
+	// The candidate is not part of the voter pool, the quorum is checked the same way as in a vote
+	if tally.VoterPoolSize != 2 {
+		t.Errorf("QueryKickProposal should exclude the candidate from the voter pool, got a pool of %v", tally.VoterPoolSize)
+	}
+	if tally.RequiredApprovals != types.NecessaryApprovals(2, uint64(types.DefaultQuorum)) {
+		t.Errorf("QueryKickProposal should require %v approvals, got %v", types.NecessaryApprovals(2, uint64(types.DefaultQuorum)), tally.RequiredApprovals)
+	}
+	if len(tally.RemainingVoters) != 1 || !tally.RemainingVoters[0].Equals(voter2.GetOperator()) {
+		t.Errorf("QueryKickProposal should have %v as remaining voter, got %v", voter2.GetOperator(), tally.RemainingVoters)
+	}
+	if !tally.CanPass {
+		t.Errorf("QueryKickProposal should be able to pass")
+	}
+
+	// No kick proposal for this validator
+	req.Data = types.ModuleCdc.MustMarshalJSON(types.NewQueryValidatorParams(voter1.GetOperator()))
+	_, err = querier(ctx, []string{types.QueryKickProposal}, req)
+	if err == nil || err.Error() != types.ErrNoKickProposalFound.Error() {
+		t.Errorf("QueryKickProposal with no kick proposal, error should be %v, got %v", types.ErrNoKickProposalFound, err)
+	}
+}
+
+func TestQueryProposalHistory(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	querier := keeper.NewQuerier(poaKeeper)
+	candidate1, _ := poa.MockValidator()
+	candidate2, _ := poa.MockValidator()
+
+	poaKeeper.ArchiveProposal(ctx, types.VoteTypeApplication, types.NewVote(candidate1), types.ProposalOutcomeRejected)
+	poaKeeper.ArchiveProposal(ctx, types.VoteTypeApplication, types.NewVote(candidate2), types.ProposalOutcomeRejected)
+	poaKeeper.ArchiveProposal(ctx, types.VoteTypeApplication, types.NewVote(candidate1), types.ProposalOutcomeApproved)
+
+	queryHistory := func(params types.QueryProposalHistoryParams) ([]types.ClosedProposal, error) {
+		req := abci.RequestQuery{
+			Data: types.ModuleCdc.MustMarshalJSON(params),
+		}
+		res, err := querier(ctx, []string{types.QueryProposalHistory}, req)
+		if err != nil {
+			return nil, err
+		}
+		var closedProposals []types.ClosedProposal
+		types.ModuleCdc.MustUnmarshalJSON(res, &closedProposals)
+		return closedProposals, nil
+	}
+
+	// Filter by candidate
+	closedProposals, err := queryHistory(types.NewQueryProposalHistoryParams(1, 0, candidate1.GetOperator(), ""))
+	if err != nil || len(closedProposals) != 2 {
+		t.Errorf("QueryProposalHistory by candidate should return 2 proposals, got %v, error %v", len(closedProposals), err)
+	}
+
+	// Filter by outcome and paginate
+	closedProposals, err = queryHistory(types.NewQueryProposalHistoryParams(2, 1, nil, types.ProposalOutcomeRejected))
+	if err != nil || len(closedProposals) != 1 {
+		t.Fatalf("QueryProposalHistory page 2 with limit 1 should return 1 proposal, got %v, error %v", len(closedProposals), err)
+	}
+	if closedProposals[0].ID != 1 {
+		t.Errorf("QueryProposalHistory page 2 of the rejected proposals should return the proposal 1, got %v", closedProposals[0].ID)
+	}
+
+	// Invalid outcome
+	_, err = queryHistory(types.NewQueryProposalHistoryParams(1, 0, nil, "unknown"))
+	if err == nil {
+		t.Errorf("QueryProposalHistory with an unknown outcome should fail")
+	}
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"encoding/binary"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ltacker/poa/types"
 )
@@ -47,6 +49,86 @@ func (k Keeper) GetValidatorState(ctx sdk.Context, addr sdk.ValAddress) (state u
 	return state, true
 }
 
+// Get the height a validator entered its current state
+// The height is 0 for a validator that has not changed state since genesis or since the upgrade recording the heights
+func (k Keeper) GetValidatorStateHeight(ctx sdk.Context, addr sdk.ValAddress) int64 {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.GetValidatorStateHeightKey(addr))
+	if value == nil {
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(value))
+}
+
+// Get the height a validator joined the validator set
+// The height is 0 for a genesis validator or a validator appended before the upgrade recording the join heights
+func (k Keeper) GetValidatorJoinHeight(ctx sdk.Context, addr sdk.ValAddress) int64 {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.GetValidatorJoinHeightKey(addr))
+	if value == nil {
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(value))
+}
+
+// Get the number of validators
+func (k Keeper) GetValidatorCount(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.ValidatorCountKey)
+	if value == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(value)
+}
+
+// Get the number of validators in a state
+func (k Keeper) GetValidatorStateCount(ctx sdk.Context, state uint16) uint64 {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.GetValidatorStateCountKey(state))
+	if value == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(value)
+}
+
+// Get the number of validators in the Tendermint validator set at the end of the block
+// The joined and joining validators remain in the set, the jailing and leaving validators leave it at the end of the block
+// and the jailed validators are out of the set
+func (k Keeper) GetBondedValidatorCount(ctx sdk.Context) uint64 {
+	return k.GetValidatorStateCount(ctx, types.ValidatorStateJoined) + k.GetValidatorStateCount(ctx, types.ValidatorStateJoining)
+}
+
+// Check if the validator is the last validator in the Tendermint validator set at the end of the block
+// The Tendermint validator set can't be empty, the last bonded validator can't leave
+func (k Keeper) IsLastBondedValidator(ctx sdk.Context, addr sdk.ValAddress) bool {
+	state, found := k.GetValidatorState(ctx, addr)
+	if !found || (state != types.ValidatorStateJoined && state != types.ValidatorStateJoining) {
+		return false
+	}
+
+	return k.GetBondedValidatorCount(ctx) <= 1
+}
+
+// Set the number of validators
+func (k Keeper) setValidatorCount(ctx sdk.Context, count uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.ValidatorCountKey, sdk.Uint64ToBigEndian(count))
+}
+
+// Set the number of validators in a state
+func (k Keeper) setValidatorStateCount(ctx sdk.Context, state uint16, count uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetValidatorStateCountKey(state), sdk.Uint64ToBigEndian(count))
+}
+
 // Set validator details
 func (k Keeper) SetValidator(ctx sdk.Context, validator types.Validator) {
 	store := ctx.KVStore(k.storeKey)
@@ -62,20 +144,55 @@ func (k Keeper) SetValidatorByConsAddr(ctx sdk.Context, validator types.Validato
 
 // Set validator state
 func (k Keeper) SetValidatorState(ctx sdk.Context, validator types.Validator, state uint16) {
-	if state != types.ValidatorStateJoining && state != types.ValidatorStateJoined && state != types.ValidatorStateLeaving {
+	if state != types.ValidatorStateJoining && state != types.ValidatorStateJoined && state != types.ValidatorStateLeaving &&
+		state != types.ValidatorStateJailing && state != types.ValidatorStateJailed {
 		panic("Incorrect validator state")
 	}
 
+	// Record the height the validator enters a new state
+	// The validator is counted when it gets its first state
+	currentState, found := k.GetValidatorState(ctx, validator.OperatorAddress)
 	store := ctx.KVStore(k.storeKey)
+	if !found {
+		k.setValidatorCount(ctx, k.GetValidatorCount(ctx)+1)
+	} else if currentState != state {
+		k.setValidatorStateCount(ctx, currentState, k.GetValidatorStateCount(ctx, currentState)-1)
+	}
+	if !found || currentState != state {
+		k.setValidatorStateCount(ctx, state, k.GetValidatorStateCount(ctx, state)+1)
+		store.Set(types.GetValidatorStateHeightKey(validator.OperatorAddress), sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
+	}
+
 	bz := []byte{byte(state)} // The state can be encoded in a single byte
 	store.Set(types.GetValidatorStateKey(validator.OperatorAddress), bz)
 }
 
+// Check if a jailed validator has been removed from the Tendermint validator set
+func (k Keeper) IsValidatorOutOfSet(ctx sdk.Context, addr sdk.ValAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetValidatorOutOfSetKey(addr))
+}
+
+// Mark a jailed validator as removed from the Tendermint validator set
+func (k Keeper) SetValidatorOutOfSet(ctx sdk.Context, addr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetValidatorOutOfSetKey(addr), []byte{})
+}
+
+// Unmark a jailed validator as removed from the Tendermint validator set
+func (k Keeper) RemoveValidatorOutOfSet(ctx sdk.Context, addr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetValidatorOutOfSetKey(addr))
+}
+
 // Append a validator and set its state to joining
 func (k Keeper) AppendValidator(ctx sdk.Context, validator types.Validator) {
 	k.SetValidator(ctx, validator)
 	k.SetValidatorByConsAddr(ctx, validator)
 	k.SetValidatorState(ctx, validator, types.ValidatorStateJoining)
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetValidatorJoinHeightKey(validator.OperatorAddress), sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
 }
 
 // Remove the validator
@@ -89,11 +206,65 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.ValAddress) {
 
 	consAddr := validator.GetConsAddr()
 
+	// The validator is no longer counted
+	state, found := k.GetValidatorState(ctx, address)
+	if found {
+		k.setValidatorCount(ctx, k.GetValidatorCount(ctx)-1)
+		k.setValidatorStateCount(ctx, state, k.GetValidatorStateCount(ctx, state)-1)
+	}
+
 	// delete the validator record
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(types.GetValidatorKey(address))
 	store.Delete(types.GetValidatorByConsAddrKey(consAddr))
 	store.Delete(types.GetValidatorStateKey(address))
+	store.Delete(types.GetValidatorStateHeightKey(address))
+	store.Delete(types.GetValidatorJoinHeightKey(address))
+	store.Delete(types.GetValidatorOutOfSetKey(address))
+	store.Delete(types.GetValidatorRewardsKey(address))
+	store.Delete(types.GetWithdrawAddrKey(address))
+	store.Delete(types.GetParticipationKey(address))
+}
+
+// A validator removed at the end of a block is in the validator set of the next block
+// Its signature of this block is in the last commit of the second next block
+const removedValidatorCommitDelay int64 = 2
+
+// Queue a validator removed at the end of the block until it is no longer in the last commit
+func (k Keeper) QueueRemovedValidator(ctx sdk.Context, validator types.Validator) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetRemovedValidatorQueueKey(ctx.BlockHeight(), validator.GetConsAddr()), validator.GetOperator())
+}
+
+// Call AfterValidatorRemoved for the queued validators that are no longer in the last commit and dequeue them
+// The hook is not called if the consensus address belongs to a validator again
+func (k Keeper) DequeueRemovedValidators(ctx sdk.Context) {
+	if ctx.BlockHeight() < removedValidatorCommitDelay {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+
+	// The validators are dequeued after the iteration
+	var keys [][]byte
+	var consAddrs []sdk.ConsAddress
+	var valAddrs []sdk.ValAddress
+	prefixLen := len(types.GetRemovedValidatorQueueHeightKey(0))
+	end := types.GetRemovedValidatorQueueHeightKey(ctx.BlockHeight() - removedValidatorCommitDelay + 1)
+	iterator := store.Iterator(types.RemovedValidatorQueueKey, end)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+		consAddrs = append(consAddrs, sdk.ConsAddress(iterator.Key()[prefixLen:]))
+		valAddrs = append(valAddrs, sdk.ValAddress(iterator.Value()))
+	}
+	iterator.Close()
+
+	for i, key := range keys {
+		store.Delete(key)
+		if _, found := k.GetValidatorByConsAddr(ctx, consAddrs[i]); found {
+			continue
+		}
+		k.AfterValidatorRemoved(ctx, consAddrs[i], valAddrs[i])
+	}
 }
 
 // Get the set of all validators
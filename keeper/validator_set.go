@@ -0,0 +1,201 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingexported "github.com/cosmos/cosmos-sdk/x/staking/exported"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/ltacker/poa/types"
+)
+
+// The keeper implements the staking validator set used by modules like slashing or evidence
+var _ stakingtypes.ValidatorSet = Keeper{}
+
+// Get the staking validator from a validator
+func (k Keeper) stakingValidator(ctx sdk.Context, validator types.Validator) types.StakingValidator {
+	state, found := k.GetValidatorState(ctx, validator.GetOperator())
+	if !found {
+		panic("A validator has no state")
+	}
+
+	return types.NewStakingValidator(validator, state, k.IsValidatorOutOfSet(ctx, validator.GetOperator()))
+}
+
+// Get a validator as a staking validator
+func (k Keeper) Validator(ctx sdk.Context, addr sdk.ValAddress) stakingexported.ValidatorI {
+	validator, found := k.GetValidator(ctx, addr)
+	if !found {
+		return nil
+	}
+
+	return k.stakingValidator(ctx, validator)
+}
+
+// Get a validator as a staking validator by consensus address
+func (k Keeper) ValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) stakingexported.ValidatorI {
+	validator, found := k.GetValidatorByConsAddr(ctx, consAddr)
+	if !found {
+		return nil
+	}
+
+	return k.stakingValidator(ctx, validator)
+}
+
+// Iterate through all the validators
+func (k Keeper) IterateValidators(ctx sdk.Context, fn func(index int64, validator stakingexported.ValidatorI) (stop bool)) {
+	for i, validator := range k.GetAllValidators(ctx) {
+		if fn(int64(i), k.stakingValidator(ctx, validator)) {
+			return
+		}
+	}
+}
+
+// Iterate through the validators present in Tendermint validator set
+// All the validators have the same power
+func (k Keeper) IterateBondedValidatorsByPower(ctx sdk.Context, fn func(index int64, validator stakingexported.ValidatorI) (stop bool)) {
+	var i int64
+	for _, validator := range k.GetAllValidators(ctx) {
+		stakingValidator := k.stakingValidator(ctx, validator)
+		if !stakingValidator.IsBonded() {
+			continue
+		}
+
+		if fn(i, stakingValidator) {
+			return
+		}
+		i++
+	}
+}
+
+// Iterate through the validators present in Tendermint validator set
+// The validator set is only updated by the End Blocker, the current set is therefore the set of the last block
+func (k Keeper) IterateLastValidators(ctx sdk.Context, fn func(index int64, validator stakingexported.ValidatorI) (stop bool)) {
+	k.IterateBondedValidatorsByPower(ctx, fn)
+}
+
+// Get the tokens of the validators present in Tendermint validator set
+func (k Keeper) TotalBondedTokens(ctx sdk.Context) sdk.Int {
+	total := sdk.ZeroInt()
+	k.IterateBondedValidatorsByPower(ctx, func(_ int64, validator stakingexported.ValidatorI) bool {
+		total = total.Add(validator.GetBondedTokens())
+		return false
+	})
+
+	return total
+}
+
+// Get the tokens of all the validators
+func (k Keeper) StakingTokenSupply(ctx sdk.Context) sdk.Int {
+	total := sdk.ZeroInt()
+	k.IterateValidators(ctx, func(_ int64, validator stakingexported.ValidatorI) bool {
+		total = total.Add(validator.GetTokens())
+		return false
+	})
+
+	return total
+}
+
+// Slash a validator
+// The validators don't have any stake, slashing has therefore no effect
+func (k Keeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeight int64, power int64, slashFactor sdk.Dec) {
+	k.Logger(ctx).Info(fmt.Sprintf("validator %s slashed by %s for an infraction at height %d, no stake to slash", consAddr, slashFactor, infractionHeight))
+}
+
+// Jail a validator, it is removed from Tendermint validator set at the end of the block but remains in the validator set
+func (k Keeper) Jail(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	validator, found := k.GetValidatorByConsAddr(ctx, consAddr)
+	if !found {
+		panic(fmt.Sprintf("validator with consensus-Address %s not found", consAddr))
+	}
+	state, found := k.GetValidatorState(ctx, validator.GetOperator())
+	if !found {
+		panic("A validator has no state")
+	}
+
+	switch state {
+	case types.ValidatorStateJoined:
+		// The End Blocker will remove the validator from Tendermint validator set
+		k.SetValidatorState(ctx, validator, types.ValidatorStateJailing)
+	case types.ValidatorStateJoining:
+		// The validator is not yet in Tendermint validator set
+		k.SetValidatorState(ctx, validator, types.ValidatorStateJailed)
+		k.SetValidatorOutOfSet(ctx, validator.GetOperator())
+	default:
+		// The validator is already jailed or leaving
+		return
+	}
+
+	k.Logger(ctx).Info(fmt.Sprintf("validator %s jailed", consAddr))
+}
+
+// Unjail a validator, it joins back Tendermint validator set at the end of the block
+func (k Keeper) Unjail(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	validator, found := k.GetValidatorByConsAddr(ctx, consAddr)
+	if !found {
+		panic(fmt.Sprintf("validator with consensus-Address %s not found", consAddr))
+	}
+	state, found := k.GetValidatorState(ctx, validator.GetOperator())
+	if !found {
+		panic("A validator has no state")
+	}
+
+	switch state {
+	case types.ValidatorStateJailing:
+		// The validator has not been removed from Tendermint validator set yet
+		k.SetValidatorState(ctx, validator, types.ValidatorStateJoined)
+	case types.ValidatorStateJailed:
+		// The End Blocker will append the validator back to Tendermint validator set
+		k.SetValidatorState(ctx, validator, types.ValidatorStateJoining)
+		k.RemoveValidatorOutOfSet(ctx, validator.GetOperator())
+	default:
+		// The validator is not jailed
+		return
+	}
+
+	k.Logger(ctx).Info(fmt.Sprintf("validator %s unjailed", consAddr))
+}
+
+// Get a delegation, a validator only has a self delegation from its operator
+func (k Keeper) Delegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) stakingexported.DelegationI {
+	if !sdk.ValAddress(delAddr).Equals(valAddr) {
+		return nil
+	}
+	if _, found := k.GetValidator(ctx, valAddr); !found {
+		return nil
+	}
+
+	return types.SelfDelegation{ValidatorAddr: valAddr}
+}
+
+// StakingHooks wraps staking hooks, like the slashing hooks, to call them on the validator set changes of the poa module
+type StakingHooks struct {
+	hooks stakingtypes.StakingHooks
+}
+
+var _ types.PoaHooks = StakingHooks{}
+
+// Create poa hooks from staking hooks
+func NewStakingHooks(hooks stakingtypes.StakingHooks) StakingHooks {
+	return StakingHooks{
+		hooks: hooks,
+	}
+}
+
+// nolint
+func (h StakingHooks) AfterApplicationSubmitted(_ sdk.Context, _ sdk.ValAddress) {}
+func (h StakingHooks) AfterValidatorAppended(ctx sdk.Context, valAddr sdk.ValAddress) {
+	h.hooks.AfterValidatorCreated(ctx, valAddr)
+}
+func (h StakingHooks) AfterValidatorJoined(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) {
+	h.hooks.AfterValidatorBonded(ctx, consAddr, valAddr)
+}
+func (h StakingHooks) AfterKickProposed(_ sdk.Context, _ sdk.ValAddress, _ sdk.ValAddress) {}
+
+func (h StakingHooks) BeforeValidatorRemoved(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress) {}
+
+// AfterValidatorRemoved is called once the removed validator is no longer in the last commit
+// Slashing deletes the pubkey of the validator and panics if it handles the signature of an unknown validator
+func (h StakingHooks) AfterValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) {
+	h.hooks.AfterValidatorRemoved(ctx, consAddr, valAddr)
+}
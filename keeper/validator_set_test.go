@@ -0,0 +1,139 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingexported "github.com/cosmos/cosmos-sdk/x/staking/exported"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/types"
+)
+
+func TestValidatorByConsAddr(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.SetValidatorState(ctx, validator1, types.ValidatorStateJoined)
+
+	// Should find the validator as a bonded staking validator
+	stakingValidator := poaKeeper.ValidatorByConsAddr(ctx, validator1.GetConsAddr())
+	if stakingValidator == nil {
+		t.Fatalf("ValidatorByConsAddr should find the validator")
+	}
+	if !stakingValidator.GetOperator().Equals(validator1.GetOperator()) {
+		t.Errorf("ValidatorByConsAddr should find %v, found %v", validator1.GetOperator(), stakingValidator.GetOperator())
+	}
+	if !stakingValidator.IsBonded() || stakingValidator.GetConsensusPower() != 1 {
+		t.Errorf("A joined validator should be bonded with a power of 1")
+	}
+
+	// Should not find a unset validator
+	if poaKeeper.ValidatorByConsAddr(ctx, validator2.GetConsAddr()) != nil {
+		t.Errorf("ValidatorByConsAddr should not find validator if it has not been set")
+	}
+	if poaKeeper.Validator(ctx, validator2.GetOperator()) != nil {
+		t.Errorf("Validator should not find validator if it has not been set")
+	}
+}
+
+func TestIterateValidators(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.AppendValidator(ctx, validator2)
+	poaKeeper.SetValidatorState(ctx, validator1, types.ValidatorStateJoined)
+
+	count := 0
+	poaKeeper.IterateValidators(ctx, func(_ int64, _ stakingexported.ValidatorI) bool {
+		count++
+		return false
+	})
+	if count != 2 {
+		t.Errorf("IterateValidators should iterate 2 validators, got %v", count)
+	}
+
+	// Only the joined validator is bonded
+	bondedCount := 0
+	poaKeeper.IterateBondedValidatorsByPower(ctx, func(_ int64, _ stakingexported.ValidatorI) bool {
+		bondedCount++
+		return false
+	})
+	if bondedCount != 1 {
+		t.Errorf("IterateBondedValidatorsByPower should iterate 1 validator, got %v", bondedCount)
+	}
+	if !poaKeeper.TotalBondedTokens(ctx).Equal(sdk.TokensFromConsensusPower(1)) {
+		t.Errorf("TotalBondedTokens should be the tokens of 1 validator, got %v", poaKeeper.TotalBondedTokens(ctx))
+	}
+}
+
+func TestJail(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	joined, _ := poa.MockValidator()
+	joining, _ := poa.MockValidator()
+
+	poaKeeper.AppendValidator(ctx, joined)
+	poaKeeper.AppendValidator(ctx, joining)
+	poaKeeper.SetValidatorState(ctx, joined, types.ValidatorStateJoined)
+
+	// A joined validator is jailing until the end of the block
+	poaKeeper.Jail(ctx, joined.GetConsAddr())
+	state, _ := poaKeeper.GetValidatorState(ctx, joined.GetOperator())
+	if state != types.ValidatorStateJailing {
+		t.Errorf("Jail should set a joined validator to jailing, got %v", state)
+	}
+	if !poaKeeper.ValidatorByConsAddr(ctx, joined.GetConsAddr()).IsJailed() {
+		t.Errorf("A jailing validator should be jailed")
+	}
+
+	// A joining validator is directly jailed
+	poaKeeper.Jail(ctx, joining.GetConsAddr())
+	state, _ = poaKeeper.GetValidatorState(ctx, joining.GetOperator())
+	if state != types.ValidatorStateJailed {
+		t.Errorf("Jail should set a joining validator to jailed, got %v", state)
+	}
+	if !poaKeeper.IsValidatorOutOfSet(ctx, joining.GetOperator()) {
+		t.Errorf("A jailed joining validator should be out of Tendermint validator set")
+	}
+
+	// Unjail a jailing validator sets it back to joined
+	poaKeeper.Unjail(ctx, joined.GetConsAddr())
+	state, _ = poaKeeper.GetValidatorState(ctx, joined.GetOperator())
+	if state != types.ValidatorStateJoined {
+		t.Errorf("Unjail should set a jailing validator to joined, got %v", state)
+	}
+
+	// Unjail a jailed validator sets it to joining
+	poaKeeper.Unjail(ctx, joining.GetConsAddr())
+	state, _ = poaKeeper.GetValidatorState(ctx, joining.GetOperator())
+	if state != types.ValidatorStateJoining {
+		t.Errorf("Unjail should set a jailed validator to joining, got %v", state)
+	}
+	if poaKeeper.IsValidatorOutOfSet(ctx, joining.GetOperator()) {
+		t.Errorf("An unjailed validator should not be out of Tendermint validator set")
+	}
+}
+
+func TestDelegation(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator, _ := poa.MockValidator()
+	other := poa.MockValAddress()
+
+	poaKeeper.AppendValidator(ctx, validator)
+
+	// Only the self delegation exists
+	delegation := poaKeeper.Delegation(ctx, sdk.AccAddress(validator.GetOperator()), validator.GetOperator())
+	if delegation == nil {
+		t.Fatalf("Delegation should return the self delegation of a validator")
+	}
+	if !delegation.GetShares().Equal(sdk.TokensFromConsensusPower(1).ToDec()) {
+		t.Errorf("The self delegation should have the shares of a power of 1, got %v", delegation.GetShares())
+	}
+	if poaKeeper.Delegation(ctx, sdk.AccAddress(other), validator.GetOperator()) != nil {
+		t.Errorf("Delegation should not return a delegation from another account")
+	}
+}
@@ -1,8 +1,10 @@
 package keeper_test
 
 import (
+	"fmt"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/google/go-cmp/cmp"
 	"github.com/ltacker/poa"
 	"github.com/ltacker/poa/types"
@@ -160,3 +162,127 @@ func TestGetAllValidators(t *testing.T) {
 		t.Errorf("GetAllValidators should find %v validators, found %v", 2, len(retrievedValidators))
 	}
 }
+
+func TestGetValidatorCount(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	validator3, _ := poa.MockValidator()
+
+	if poaKeeper.GetValidatorCount(ctx) != 0 {
+		t.Errorf("GetValidatorCount should return 0 without validator, got %v", poaKeeper.GetValidatorCount(ctx))
+	}
+
+	// The appended validators are joining
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.AppendValidator(ctx, validator2)
+	poaKeeper.AppendValidator(ctx, validator3)
+	if poaKeeper.GetValidatorCount(ctx) != 3 {
+		t.Errorf("GetValidatorCount should return 3, got %v", poaKeeper.GetValidatorCount(ctx))
+	}
+	if poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateJoining) != 3 {
+		t.Errorf("GetValidatorStateCount should return 3 joining validators, got %v", poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateJoining))
+	}
+
+	// A new state moves the validator to the counter of the state
+	poaKeeper.SetValidatorState(ctx, validator1, types.ValidatorStateJoined)
+	poaKeeper.SetValidatorState(ctx, validator2, types.ValidatorStateJoined)
+	poaKeeper.SetValidatorState(ctx, validator2, types.ValidatorStateJoined)
+	poaKeeper.SetValidatorState(ctx, validator3, types.ValidatorStateLeaving)
+	if poaKeeper.GetValidatorCount(ctx) != 3 {
+		t.Errorf("GetValidatorCount should still return 3, got %v", poaKeeper.GetValidatorCount(ctx))
+	}
+	if poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateJoining) != 0 {
+		t.Errorf("GetValidatorStateCount should return 0 joining validator, got %v", poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateJoining))
+	}
+	if poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateJoined) != 2 {
+		t.Errorf("GetValidatorStateCount should return 2 joined validators, got %v", poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateJoined))
+	}
+	if poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateLeaving) != 1 {
+		t.Errorf("GetValidatorStateCount should return 1 leaving validator, got %v", poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateLeaving))
+	}
+
+	// A removed validator is no longer counted
+	poaKeeper.RemoveValidator(ctx, validator3.GetOperator())
+	poaKeeper.RemoveValidator(ctx, validator3.GetOperator())
+	if poaKeeper.GetValidatorCount(ctx) != 2 {
+		t.Errorf("GetValidatorCount should return 2 after a removal, got %v", poaKeeper.GetValidatorCount(ctx))
+	}
+	if poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateLeaving) != 0 {
+		t.Errorf("GetValidatorStateCount should return 0 leaving validator after the removal, got %v", poaKeeper.GetValidatorStateCount(ctx, types.ValidatorStateLeaving))
+	}
+}
+
+// Compare counting the validators by iterating the validator set and by reading the counter
+// The gas consumed by each count is reported with the gas/op metric
+func BenchmarkValidatorCount(b *testing.B) {
+	for _, size := range []int{100, 200} {
+		ctx, poaKeeper := poa.MockContext()
+		for i := 0; i < size; i++ {
+			validator, _ := poa.MockValidator()
+			poaKeeper.AppendValidator(ctx, validator)
+		}
+
+		count := map[string]func(ctx sdk.Context) uint64{
+			"GetAllValidators": func(ctx sdk.Context) uint64 {
+				return uint64(len(poaKeeper.GetAllValidators(ctx)))
+			},
+			"GetValidatorCount": poaKeeper.GetValidatorCount,
+		}
+		for _, name := range []string{"GetAllValidators", "GetValidatorCount"} {
+			b.Run(fmt.Sprintf("%s/%d", name, size), func(b *testing.B) {
+				gasMeter := sdk.NewInfiniteGasMeter()
+				gasCtx := ctx.WithGasMeter(gasMeter)
+				for i := 0; i < b.N; i++ {
+					if count[name](gasCtx) != uint64(size) {
+						b.Fatalf("%v should count %v validators", name, size)
+					}
+				}
+				b.ReportMetric(float64(gasMeter.GasConsumed())/float64(b.N), "gas/op")
+			})
+		}
+	}
+}
+
+// Hooks recording the removed validators, the other hooks are not called
+type removedHooks struct {
+	types.PoaHooks
+	removed []sdk.ValAddress
+}
+
+func (h *removedHooks) AfterValidatorRemoved(_ sdk.Context, _ sdk.ConsAddress, valAddr sdk.ValAddress) {
+	h.removed = append(h.removed, valAddr)
+}
+
+func TestDequeueRemovedValidators(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	hooks := &removedHooks{}
+	poaKeeper.SetHooks(hooks)
+	removed, _ := poa.MockValidator()
+	readded, _ := poa.MockValidator()
+
+	// Both validators are removed at height 10, the second one is appended back
+	ctx = ctx.WithBlockHeight(10)
+	poaKeeper.QueueRemovedValidator(ctx, removed)
+	poaKeeper.QueueRemovedValidator(ctx, readded)
+	poaKeeper.SetValidator(ctx, readded)
+	poaKeeper.SetValidatorByConsAddr(ctx, readded)
+
+	// The validators are still in the last commit at height 11
+	poaKeeper.DequeueRemovedValidators(ctx.WithBlockHeight(11))
+	if len(hooks.removed) != 0 {
+		t.Errorf("DequeueRemovedValidators should not dequeue a validator still in the last commit, got %v", hooks.removed)
+	}
+
+	// The hook is only called for the validator not appended back
+	poaKeeper.DequeueRemovedValidators(ctx.WithBlockHeight(12))
+	if !cmp.Equal(hooks.removed, []sdk.ValAddress{removed.GetOperator()}) {
+		t.Errorf("DequeueRemovedValidators should call AfterValidatorRemoved for %v, got %v", removed.GetOperator(), hooks.removed)
+	}
+
+	// The validators are dequeued
+	poaKeeper.DequeueRemovedValidators(ctx.WithBlockHeight(13))
+	if len(hooks.removed) != 1 {
+		t.Errorf("DequeueRemovedValidators should dequeue the validators, got %v", hooks.removed)
+	}
+}
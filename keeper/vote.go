@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ltacker/poa/types"
+)
+
+// A single byte represents the option of a voter
+// The option is unknown for the votes migrated from a store that didn't record the approvers
+const (
+	voteOptionReject  = byte(0)
+	voteOptionApprove = byte(1)
+	voteOptionUnknown = byte(2)
+)
+
+// Decode the choice of a voter from its option
+func voterChoiceFromOption(voterAddr sdk.ValAddress, option byte) types.VoterChoice {
+	return types.VoterChoice{
+		Voter:   voterAddr,
+		Approve: option == voteOptionApprove,
+		Unknown: option == voteOptionUnknown,
+	}
+}
+
+// Get the choice of a voter in the application or the kick proposal against a candidate
+func (k Keeper) GetVoterChoice(ctx sdk.Context, voteType uint16, candidateAddr sdk.ValAddress, voterAddr sdk.ValAddress) (choice types.VoterChoice, found bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.GetVoteKey(voteType, candidateAddr, voterAddr))
+	if value == nil {
+		return choice, false
+	}
+
+	return voterChoiceFromOption(voterAddr, value[0]), true
+}
+
+// Set the choice of a voter in the application or the kick proposal against a candidate
+func (k Keeper) SetVoterChoice(ctx sdk.Context, voteType uint16, candidateAddr sdk.ValAddress, choice types.VoterChoice) {
+	store := ctx.KVStore(k.storeKey)
+
+	option := voteOptionReject
+	if choice.Unknown {
+		option = voteOptionUnknown
+	} else if choice.Approve {
+		option = voteOptionApprove
+	}
+	store.Set(types.GetVoteKey(voteType, candidateAddr, choice.Voter), []byte{option})
+}
+
+// Iterate through the choices of the voters in the application or the kick proposal against a candidate
+func (k Keeper) IterateVoterChoices(ctx sdk.Context, voteType uint16, candidateAddr sdk.ValAddress, fn func(choice types.VoterChoice) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.GetVotesKey(voteType, candidateAddr)
+
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		choice := voterChoiceFromOption(sdk.ValAddress(iterator.Key()[len(prefix):]), iterator.Value()[0])
+		if fn(choice) {
+			break
+		}
+	}
+}
+
+// Get the choices of all the voters in the application or the kick proposal against a candidate
+func (k Keeper) GetAllVoterChoices(ctx sdk.Context, voteType uint16, candidateAddr sdk.ValAddress) (choices []types.VoterChoice) {
+	k.IterateVoterChoices(ctx, voteType, candidateAddr, func(choice types.VoterChoice) bool {
+		choices = append(choices, choice)
+		return false
+	})
+
+	return choices
+}
+
+// Check if a voter voted in the application or the kick proposal against a candidate
+func (k Keeper) HasVoted(ctx sdk.Context, voteType uint16, candidateAddr sdk.ValAddress, voterAddr sdk.ValAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetVoteKey(voteType, candidateAddr, voterAddr))
+}
+
+// Add the vote of a voter in an application or a kick proposal
+// The choice of the voter is stored and counted in the tally counters of the vote, the vote itself must be set by the caller
+func (k Keeper) AddVote(ctx sdk.Context, voteType uint16, vote *types.Vote, voterAddr sdk.ValAddress, approve bool) (alreadyVoted bool) {
+	candidateAddr := vote.GetSubject().GetOperator()
+
+	// Verify if the voter already voted
+	if k.HasVoted(ctx, voteType, candidateAddr, voterAddr) {
+		return true
+	}
+
+	k.SetVoterChoice(ctx, voteType, candidateAddr, types.VoterChoice{
+		Voter:   voterAddr,
+		Approve: approve,
+	})
+	vote.CountVote(approve)
+
+	return false
+}
+
+// Get the height the application or the kick proposal against a candidate has been opened
+// The height is 0 for a proposal opened before the upgrade recording the heights
+func (k Keeper) GetProposalOpenHeight(ctx sdk.Context, voteType uint16, candidateAddr sdk.ValAddress) int64 {
+	store := ctx.KVStore(k.storeKey)
+
+	value := store.Get(types.GetProposalOpenHeightKey(voteType, candidateAddr))
+	if value == nil {
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(value))
+}
+
+// Record the current height as the height the application or the kick proposal against a candidate has been opened
+func (k Keeper) setProposalOpenHeight(ctx sdk.Context, voteType uint16, candidateAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetProposalOpenHeightKey(voteType, candidateAddr), sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
+}
+
+// Remove the choices of all the voters in the application or the kick proposal against a candidate
+func (k Keeper) removeVoterChoices(ctx sdk.Context, voteType uint16, candidateAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+
+	// The keys are deleted after the iteration
+	var keys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, types.GetVotesKey(voteType, candidateAddr))
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
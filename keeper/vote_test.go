@@ -0,0 +1,122 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/types"
+)
+
+func TestAddVote(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	candidate, _ := poa.MockValidator()
+	voter1 := poa.MockValAddress()
+	voter2 := poa.MockValAddress()
+	application := types.NewVote(candidate)
+
+	alreadyVoted := poaKeeper.AddVote(ctx, types.VoteTypeApplication, &application, voter1, true)
+	if alreadyVoted {
+		t.Errorf("AddVote should return false if the voter hasn't voted yet")
+	}
+	if application.GetTotal() != 1 || application.GetApprovals() != 1 {
+		t.Errorf("AddVote with approval should count 1 approval out of 1 vote, got %v out of %v", application.GetApprovals(), application.GetTotal())
+	}
+
+	alreadyVoted = poaKeeper.AddVote(ctx, types.VoteTypeApplication, &application, voter2, false)
+	if alreadyVoted {
+		t.Errorf("AddVote should return false if the voter hasn't voted yet")
+	}
+	if application.GetTotal() != 2 || application.GetApprovals() != 1 {
+		t.Errorf("AddVote with reject should count 1 approval out of 2 votes, got %v out of %v", application.GetApprovals(), application.GetTotal())
+	}
+
+	// A voter can't vote twice
+	alreadyVoted = poaKeeper.AddVote(ctx, types.VoteTypeApplication, &application, voter1, false)
+	if !alreadyVoted {
+		t.Errorf("AddVote should return true if the voter has already voted")
+	}
+	if application.GetTotal() != 2 {
+		t.Errorf("AddVote should not increase the number of votes if the voter has already voted")
+	}
+	choice, found := poaKeeper.GetVoterChoice(ctx, types.VoteTypeApplication, candidate.GetOperator(), voter1)
+	if !found || !choice.Approve {
+		t.Errorf("AddVote should not change the choice of a voter who has already voted")
+	}
+
+	// The votes of the application and the kick proposal are distinct
+	if poaKeeper.HasVoted(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), voter1) {
+		t.Errorf("HasVoted should not find a vote on the kick proposal")
+	}
+}
+
+func TestGetVoterChoice(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	candidate, _ := poa.MockValidator()
+	voter1 := poa.MockValAddress()
+	voter2 := poa.MockValAddress()
+	voter3 := poa.MockValAddress()
+
+	poaKeeper.SetVoterChoice(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), types.VoterChoice{Voter: voter1, Approve: true})
+	poaKeeper.SetVoterChoice(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), types.VoterChoice{Voter: voter2, Approve: false})
+
+	choice, found := poaKeeper.GetVoterChoice(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), voter1)
+	if !found || !choice.Voter.Equals(voter1) || !choice.Approve {
+		t.Errorf("GetVoterChoice should find the approval of voter1, got %v", choice)
+	}
+	choice, found = poaKeeper.GetVoterChoice(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), voter2)
+	if !found || !choice.Voter.Equals(voter2) || choice.Approve {
+		t.Errorf("GetVoterChoice should find the rejection of voter2, got %v", choice)
+	}
+	_, found = poaKeeper.GetVoterChoice(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), voter3)
+	if found {
+		t.Errorf("GetVoterChoice should not find the choice of a voter who hasn't voted")
+	}
+
+	choices := poaKeeper.GetAllVoterChoices(ctx, types.VoteTypeKickProposal, candidate.GetOperator())
+	if len(choices) != 2 {
+		t.Errorf("GetAllVoterChoices should find 2 choices, found %v", len(choices))
+	}
+
+	// The iteration can be stopped
+	count := 0
+	poaKeeper.IterateVoterChoices(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), func(choice types.VoterChoice) bool {
+		count++
+		return true
+	})
+	if count != 1 {
+		t.Errorf("IterateVoterChoices should stop after the first choice, got %v", count)
+	}
+}
+
+func TestRemoveProposalRemovesVoterChoices(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	candidate, _ := poa.MockValidator()
+	voter := poa.MockValAddress()
+
+	poaKeeper.AppendApplication(ctx, candidate)
+	application, _ := poaKeeper.GetApplication(ctx, candidate.GetOperator())
+	poaKeeper.AddVote(ctx, types.VoteTypeApplication, &application, voter, true)
+	poaKeeper.SetApplication(ctx, application)
+	poaKeeper.AppendKickProposal(ctx, candidate)
+	kickProposal, _ := poaKeeper.GetKickProposal(ctx, candidate.GetOperator())
+	poaKeeper.AddVote(ctx, types.VoteTypeKickProposal, &kickProposal, voter, true)
+	poaKeeper.SetKickProposal(ctx, kickProposal)
+
+	// The choices are archived when the application is closed
+	poaKeeper.CloseApplication(ctx, application, types.ProposalOutcomeApproved)
+	if poaKeeper.HasVoted(ctx, types.VoteTypeApplication, candidate.GetOperator(), voter) {
+		t.Errorf("CloseApplication should remove the choices of the voters")
+	}
+	closedProposal, _ := poaKeeper.GetClosedProposal(ctx, 0)
+	if len(closedProposal.Votes) != 1 || !closedProposal.Votes[0].Voter.Equals(voter) || !closedProposal.Votes[0].Approve {
+		t.Errorf("CloseApplication should archive the choices of the voters, got %v", closedProposal.Votes)
+	}
+	if !poaKeeper.HasVoted(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), voter) {
+		t.Errorf("CloseApplication should not remove the choices of the kick proposal")
+	}
+
+	poaKeeper.RemoveKickProposal(ctx, candidate.GetOperator())
+	if poaKeeper.HasVoted(ctx, types.VoteTypeKickProposal, candidate.GetOperator(), voter) {
+		t.Errorf("RemoveKickProposal should remove the choices of the voters")
+	}
+}
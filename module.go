@@ -2,9 +2,11 @@ package poa
 
 import (
 	"encoding/json"
+	"math/rand"
 
 	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
@@ -12,16 +14,20 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
+	sim "github.com/cosmos/cosmos-sdk/x/simulation"
 	"github.com/ltacker/poa/client/cli"
 	"github.com/ltacker/poa/client/rest"
 	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/simulation"
 	"github.com/ltacker/poa/types"
+	"github.com/ltacker/poa/types/pb"
 )
 
 // Type check to ensure the interface is properly implemented
 var (
-	_ module.AppModule      = AppModule{}
-	_ module.AppModuleBasic = AppModuleBasic{}
+	_ module.AppModule           = AppModule{}
+	_ module.AppModuleBasic      = AppModuleBasic{}
+	_ module.AppModuleSimulation = AppModule{}
 )
 
 // AppModuleBasic defines the basic application module used by the poa module.
@@ -74,14 +80,17 @@ func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 type AppModule struct {
 	AppModuleBasic
 
-	keeper keeper.Keeper
+	keeper        keeper.Keeper
+	accountKeeper types.AccountKeeper
 }
 
 // NewAppModule creates a new AppModule object
-func NewAppModule(k keeper.Keeper) AppModule {
+// The account keeper is only used to sign the messages of the simulation
+func NewAppModule(k keeper.Keeper, accountKeeper types.AccountKeeper) AppModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		keeper:         k,
+		accountKeeper:  accountKeeper,
 	}
 }
 
@@ -90,8 +99,15 @@ func (AppModule) Name() string {
 	return types.ModuleName
 }
 
+// ConsensusVersion returns the consensus version of the poa module store.
+func (AppModule) ConsensusVersion() uint64 {
+	return types.ConsensusVersion
+}
+
 // RegisterInvariants registers the poa module invariants.
-func (am AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	keeper.RegisterInvariants(ir, am.keeper)
+}
 
 // Route returns the message routing key for the poa module.
 func (AppModule) Route() string {
@@ -103,6 +119,20 @@ func (am AppModule) NewHandler() sdk.Handler {
 	return NewHandler(am.keeper)
 }
 
+// RegisterServices registers the poa Query service on a gRPC server.
+// The server must carry the sdk.Context in the context of each call with types.WrapSDKContext.
+func (am AppModule) RegisterServices(server *grpc.Server) {
+	pb.RegisterQueryServer(server, keeper.NewQueryServerImpl(am.keeper))
+}
+
+// RegisterUnauthenticatedMsgService registers the poa Msg service on a gRPC server.
+// The service runs the handlers without the ante handler: the signatures, the fees and the sequences are not checked,
+// any caller can send a message on behalf of any validator. It must never be exposed on a live node, it is only meant
+// for tests and tools running on a discarded branch of the state.
+func (am AppModule) RegisterUnauthenticatedMsgService(server *grpc.Server) {
+	pb.RegisterMsgServer(server, NewMsgServerImpl(am.keeper))
+}
+
 // QuerierRoute returns the poa module's querier route name.
 func (AppModule) QuerierRoute() string {
 	return types.QuerierRoute
@@ -136,3 +166,32 @@ func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
 	return EndBlocker(ctx, am.keeper)
 }
+
+//____________________________________________________________________________
+
+// AppModuleSimulation functions
+
+// GenerateGenesisState creates a randomized GenState of the poa module.
+func (AppModule) GenerateGenesisState(simState *module.SimulationState) {
+	simulation.RandomizedGenState(simState)
+}
+
+// ProposalContents returns the poa proposals adding and removing validators.
+func (am AppModule) ProposalContents(_ module.SimulationState) []sim.WeightedProposalContent {
+	return simulation.ProposalContents(am.keeper)
+}
+
+// RandomizedParams creates randomized poa param changes for the simulator.
+func (AppModule) RandomizedParams(r *rand.Rand) []sim.ParamChange {
+	return simulation.ParamChanges(r)
+}
+
+// RegisterStoreDecoder registers a decoder for poa module's types
+func (AppModule) RegisterStoreDecoder(sdr sdk.StoreDecoderRegistry) {
+	sdr[types.StoreKey] = simulation.DecodeStore
+}
+
+// WeightedOperations returns the all the poa module operations with their respective weights.
+func (am AppModule) WeightedOperations(simState module.SimulationState) []sim.WeightedOperation {
+	return simulation.WeightedOperations(simState.AppParams, simState.Cdc, am.accountKeeper, am.keeper)
+}
@@ -0,0 +1,130 @@
+package poa
+
+import (
+	"context"
+
+	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/types"
+	"github.com/ltacker/poa/types/pb"
+)
+
+// Type check to ensure the interface is properly implemented
+var _ pb.MsgServer = msgServer{}
+
+// Implementation of the Msg service on the keeper
+// The messages are converted to their amino type and processed by the handler of the module
+type msgServer struct {
+	keeper keeper.Keeper
+}
+
+// NewMsgServerImpl returns the Msg service of the keeper
+// The context of each call must carry the sdk.Context, see types.WrapSDKContext
+// The service doesn't run the ante handler, it must never be exposed on a live node, see AppModule.RegisterUnauthenticatedMsgService
+func NewMsgServerImpl(k keeper.Keeper) pb.MsgServer {
+	return msgServer{
+		keeper: k,
+	}
+}
+
+// SubmitApplication creates an application to become a validator
+func (m msgServer) SubmitApplication(goCtx context.Context, req *pb.MsgSubmitApplication) (*pb.MsgSubmitApplicationResponse, error) {
+	msg := types.MsgSubmitApplicationFromProto(req)
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if _, err := handleMsgSubmitApplication(types.UnwrapSDKContext(goCtx), m.keeper, msg); err != nil {
+		return nil, err
+	}
+
+	return &pb.MsgSubmitApplicationResponse{}, nil
+}
+
+// ProposeKick creates a kick proposal against a validator
+func (m msgServer) ProposeKick(goCtx context.Context, req *pb.MsgProposeKick) (*pb.MsgProposeKickResponse, error) {
+	msg := types.MsgProposeKickFromProto(req)
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if _, err := handleMsgProposeKick(types.UnwrapSDKContext(goCtx), m.keeper, msg); err != nil {
+		return nil, err
+	}
+
+	return &pb.MsgProposeKickResponse{}, nil
+}
+
+// Vote approves or rejects an application or a kick proposal
+func (m msgServer) Vote(goCtx context.Context, req *pb.MsgVote) (*pb.MsgVoteResponse, error) {
+	msg := types.MsgVoteFromProto(req)
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if _, err := handleMsgVote(types.UnwrapSDKContext(goCtx), m.keeper, msg); err != nil {
+		return nil, err
+	}
+
+	return &pb.MsgVoteResponse{}, nil
+}
+
+// VoteBatch approves or rejects several applications and kick proposals
+func (m msgServer) VoteBatch(goCtx context.Context, req *pb.MsgVoteBatch) (*pb.MsgVoteBatchResponse, error) {
+	msg := types.MsgVoteBatchFromProto(req)
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if _, err := handleMsgVoteBatch(types.UnwrapSDKContext(goCtx), m.keeper, msg); err != nil {
+		return nil, err
+	}
+
+	return &pb.MsgVoteBatchResponse{}, nil
+}
+
+// LeaveValidatorSet removes the sender from the validator set
+func (m msgServer) LeaveValidatorSet(goCtx context.Context, req *pb.MsgLeaveValidatorSet) (*pb.MsgLeaveValidatorSetResponse, error) {
+	msg := types.MsgLeaveValidatorSetFromProto(req)
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if _, err := handleMsgLeaveValidatorSet(types.UnwrapSDKContext(goCtx), m.keeper, msg); err != nil {
+		return nil, err
+	}
+
+	return &pb.MsgLeaveValidatorSetResponse{}, nil
+}
+
+// SetWithdrawAddress sets the address receiving the rewards of a validator
+func (m msgServer) SetWithdrawAddress(goCtx context.Context, req *pb.MsgSetWithdrawAddress) (*pb.MsgSetWithdrawAddressResponse, error) {
+	msg := types.MsgSetWithdrawAddressFromProto(req)
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	if _, err := handleMsgSetWithdrawAddress(types.UnwrapSDKContext(goCtx), m.keeper, msg); err != nil {
+		return nil, err
+	}
+
+	return &pb.MsgSetWithdrawAddressResponse{}, nil
+}
+
+// WithdrawRewards sends the rewards of a validator to its withdraw address
+func (m msgServer) WithdrawRewards(goCtx context.Context, req *pb.MsgWithdrawRewards) (*pb.MsgWithdrawRewardsResponse, error) {
+	msg := types.MsgWithdrawRewardsFromProto(req)
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	// All the rewards are withdrawn
+	ctx := types.UnwrapSDKContext(goCtx)
+	rewards := m.keeper.GetValidatorRewards(ctx, msg.ValidatorAddr)
+	if _, err := handleMsgWithdrawRewards(ctx, m.keeper, msg); err != nil {
+		return nil, err
+	}
+
+	return &pb.MsgWithdrawRewardsResponse{
+		Amount: types.CoinsToProto(rewards),
+	}, nil
+}
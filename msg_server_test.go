@@ -0,0 +1,105 @@
+package poa_test
+
+import (
+	"testing"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/types"
+	"github.com/ltacker/poa/types/pb"
+)
+
+func TestMsgServerSubmitApplication(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	msgServer := poa.NewMsgServerImpl(poaKeeper)
+	goCtx := types.WrapSDKContext(ctx)
+	validator, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+
+	// The application is submitted through the handler
+	_, err := msgServer.SubmitApplication(goCtx, &pb.MsgSubmitApplication{Candidate: types.ValidatorToProto(validator)})
+	if err != nil {
+		t.Errorf("SubmitApplication should submit an application, got error %v", err)
+	}
+	if _, found := poaKeeper.GetApplication(ctx, validator.GetOperator()); !found {
+		t.Errorf("SubmitApplication should submit an application, the application has not been found")
+	}
+
+	// The message is validated
+	_, err = msgServer.SubmitApplication(goCtx, &pb.MsgSubmitApplication{})
+	if err == nil {
+		t.Errorf("SubmitApplication should fail for an invalid candidate")
+	}
+}
+
+func TestMsgServerVote(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	msgServer := poa.NewMsgServerImpl(poaKeeper)
+	goCtx := types.WrapSDKContext(ctx)
+	voter, _ := poa.MockValidator()
+	candidate, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	poaKeeper.AppendValidator(ctx, voter)
+	poaKeeper.SetValidatorState(ctx, voter, types.ValidatorStateJoined)
+	poaKeeper.SetApplication(ctx, types.NewVote(candidate))
+
+	_, err := msgServer.Vote(goCtx, &pb.MsgVote{
+		VoteType:      uint32(types.VoteTypeApplication),
+		VoterAddr:     voter.GetOperator(),
+		CandidateAddr: candidate.GetOperator(),
+		Approve:       true,
+	})
+	if err != nil {
+		t.Errorf("Vote should approve the application, got error %v", err)
+	}
+	if _, found := poaKeeper.GetValidator(ctx, candidate.GetOperator()); !found {
+		t.Errorf("Vote should append the candidate approved by the only validator")
+	}
+
+	// An unknown vote type is rejected
+	_, err = msgServer.Vote(goCtx, &pb.MsgVote{
+		VoteType:      1 << 16,
+		VoterAddr:     voter.GetOperator(),
+		CandidateAddr: candidate.GetOperator(),
+	})
+	if err == nil {
+		t.Errorf("Vote should fail for an unknown vote type")
+	}
+}
+
+func TestMsgServerVoteBatch(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	msgServer := poa.NewMsgServerImpl(poaKeeper)
+	goCtx := types.WrapSDKContext(ctx)
+	voter, _ := poa.MockValidator()
+	candidate1, _ := poa.MockValidator()
+	candidate2, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	poaKeeper.AppendValidator(ctx, voter)
+	poaKeeper.SetValidatorState(ctx, voter, types.ValidatorStateJoined)
+	poaKeeper.SetApplication(ctx, types.NewVote(candidate1))
+	poaKeeper.SetApplication(ctx, types.NewVote(candidate2))
+
+	// The first application is approved and the second rejected
+	_, err := msgServer.VoteBatch(goCtx, &pb.MsgVoteBatch{
+		VoterAddr: voter.GetOperator(),
+		Votes: []*pb.BatchVote{
+			{VoteType: uint32(types.VoteTypeApplication), CandidateAddr: candidate1.GetOperator(), Approve: true},
+			{VoteType: uint32(types.VoteTypeApplication), CandidateAddr: candidate2.GetOperator(), Approve: false},
+		},
+	})
+	if err != nil {
+		t.Errorf("VoteBatch should vote on the applications, got error %v", err)
+	}
+	if _, found := poaKeeper.GetValidator(ctx, candidate1.GetOperator()); !found {
+		t.Errorf("VoteBatch should append the approved candidate")
+	}
+	if _, found := poaKeeper.GetValidator(ctx, candidate2.GetOperator()); found {
+		t.Errorf("VoteBatch should not append the rejected candidate")
+	}
+
+	// An empty batch is rejected
+	_, err = msgServer.VoteBatch(goCtx, &pb.MsgVoteBatch{VoterAddr: voter.GetOperator()})
+	if err == nil {
+		t.Errorf("VoteBatch should fail for an empty batch")
+	}
+}
@@ -0,0 +1,110 @@
+package poa
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/types"
+)
+
+// NewPoaProposalHandler creates a governance handler to manage the validator set through x/gov proposals
+func NewPoaProposalHandler(k keeper.Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case types.AddValidatorProposal:
+			return handleAddValidatorProposal(ctx, k, c)
+		case types.RemoveValidatorProposal:
+			return handleRemoveValidatorProposal(ctx, k, c)
+		default:
+			errMsg := fmt.Sprintf("unrecognized %s proposal content type: %T", types.ModuleName, c)
+			return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
+		}
+	}
+}
+
+// handleAddValidatorProposal appends the validator of the proposal in the validator set without vote from the validators
+func handleAddValidatorProposal(ctx sdk.Context, k keeper.Keeper, p types.AddValidatorProposal) error {
+	// Check max validator is not reached
+	validatorCount := k.GetValidatorCount(ctx)
+	maxValidator := k.MaxValidators(ctx)
+	if validatorCount >= uint64(maxValidator) {
+		return types.ErrMaxValidatorsReached
+	}
+	// Candidate should not be a validator
+	_, found := k.GetValidator(ctx, p.Validator.GetOperator())
+	if found {
+		return types.ErrAlreadyValidator
+	}
+	_, found = k.GetValidatorByConsAddr(ctx, p.Validator.GetConsAddr())
+	if found {
+		return types.ErrAlreadyValidator
+	}
+
+	// The consensus public key should not be used by the application of another candidate
+	application, found := k.GetApplicationByConsAddr(ctx, p.Validator.GetConsAddr())
+	if found && !application.GetSubject().GetOperator().Equals(p.Validator.GetOperator()) {
+		return types.ErrAlreadyApplying
+	}
+
+	// The proposal overrides the application of the candidate
+	application, found = k.GetApplication(ctx, p.Validator.GetOperator())
+	if found {
+		k.CloseApplication(ctx, application, types.ProposalOutcomeApproved)
+	}
+
+	k.AppendValidator(ctx, p.Validator)
+	k.AfterValidatorAppended(ctx, p.Validator.GetOperator())
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeAppendValidator,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(types.AttributeKeyCandidate, p.Validator.GetOperator().String()),
+		),
+	)
+
+	return nil
+}
+
+// handleRemoveValidatorProposal removes the validator of the proposal from the validator set without vote from the validators
+func handleRemoveValidatorProposal(ctx sdk.Context, k keeper.Keeper, p types.RemoveValidatorProposal) error {
+	// Candidate should be a validator
+	validator, found := k.GetValidator(ctx, p.ValidatorAddr)
+	if !found {
+		return types.ErrNotValidator
+	}
+	valState, found := k.GetValidatorState(ctx, p.ValidatorAddr)
+	if !found {
+		panic("A validator has no state")
+	}
+	if valState == types.ValidatorStateLeaving {
+		return types.ErrValidatorLeaving
+	}
+
+	// The Tendermint validator set can't be empty
+	if k.IsLastBondedValidator(ctx, p.ValidatorAddr) {
+		return types.ErrOnlyOneValidator
+	}
+
+	// The proposal overrides the kick proposal of the validator
+	kickProposal, found := k.GetKickProposal(ctx, p.ValidatorAddr)
+	if found {
+		k.CloseKickProposal(ctx, kickProposal, types.ProposalOutcomeApproved)
+	}
+
+	// We set the validator state to leaving, the End Blocker will update the keeper
+	k.SetValidatorState(ctx, validator, types.ValidatorStateLeaving)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeKickValidator,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(types.AttributeKeyValidator, p.ValidatorAddr.String()),
+		),
+	)
+
+	return nil
+}
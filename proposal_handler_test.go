@@ -0,0 +1,119 @@
+package poa_test
+
+import (
+	"testing"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/types"
+)
+
+func TestAddValidatorProposal(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewPoaProposalHandler(poaKeeper)
+	validator, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.NewParams(2, 66))
+
+	// A pending application of the validator is overridden
+	poaKeeper.AppendApplication(ctx, validator)
+
+	proposal := types.NewAddValidatorProposal("title", "description", validator)
+	err := handler(ctx, proposal)
+	if err != nil {
+		t.Errorf("AddValidatorProposal should append the validator, got error %v", err)
+	}
+	state, found := poaKeeper.GetValidatorState(ctx, validator.GetOperator())
+	if !found || state != types.ValidatorStateJoining {
+		t.Errorf("AddValidatorProposal should append the validator as joining, found: %v, state: %v", found, state)
+	}
+	_, found = poaKeeper.GetApplication(ctx, validator.GetOperator())
+	if found {
+		t.Errorf("AddValidatorProposal should remove the application of the validator")
+	}
+
+	// The validator cannot be added twice
+	err = handler(ctx, proposal)
+	if err == nil || err.Error() != types.ErrAlreadyValidator.Error() {
+		t.Errorf("AddValidatorProposal with duplicate, error should be %v, got %v", types.ErrAlreadyValidator, err)
+	}
+
+	// The max validators is checked
+	validator2, _ := poa.MockValidator()
+	validator3, _ := poa.MockValidator()
+	poaKeeper.AppendValidator(ctx, validator2)
+	err = handler(ctx, types.NewAddValidatorProposal("title", "description", validator3))
+	if err == nil || err.Error() != types.ErrMaxValidatorsReached.Error() {
+		t.Errorf("AddValidatorProposal with max validators reached, error should be %v, got %v", types.ErrMaxValidatorsReached, err)
+	}
+
+	// The max validators is checked if it has been lowered below the number of validators
+	poaKeeper.SetParams(ctx, types.NewParams(1, 66))
+	err = handler(ctx, types.NewAddValidatorProposal("title", "description", validator3))
+	if err == nil || err.Error() != types.ErrMaxValidatorsReached.Error() {
+		t.Errorf("AddValidatorProposal above max validators, error should be %v, got %v", types.ErrMaxValidatorsReached, err)
+	}
+}
+
+func TestRemoveValidatorProposal(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewPoaProposalHandler(poaKeeper)
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+	poaKeeper.AppendValidator(ctx, validator1)
+	poaKeeper.AppendValidator(ctx, validator2)
+
+	// A kick proposal against the validator is overridden
+	poaKeeper.AppendKickProposal(ctx, validator1)
+
+	proposal := types.NewRemoveValidatorProposal("title", "description", validator1.GetOperator())
+	err := handler(ctx, proposal)
+	if err != nil {
+		t.Errorf("RemoveValidatorProposal should remove the validator, got error %v", err)
+	}
+	state, _ := poaKeeper.GetValidatorState(ctx, validator1.GetOperator())
+	if state != types.ValidatorStateLeaving {
+		t.Errorf("RemoveValidatorProposal should set the validator as leaving, got %v", state)
+	}
+	_, found := poaKeeper.GetKickProposal(ctx, validator1.GetOperator())
+	if found {
+		t.Errorf("RemoveValidatorProposal should remove the kick proposal of the validator")
+	}
+
+	// A leaving validator cannot be removed
+	err = handler(ctx, proposal)
+	if err == nil || err.Error() != types.ErrValidatorLeaving.Error() {
+		t.Errorf("RemoveValidatorProposal with leaving validator, error should be %v, got %v", types.ErrValidatorLeaving, err)
+	}
+
+	// The candidate must be a validator
+	err = handler(ctx, types.NewRemoveValidatorProposal("title", "description", poa.MockValAddress()))
+	if err == nil || err.Error() != types.ErrNotValidator.Error() {
+		t.Errorf("RemoveValidatorProposal with no validator, error should be %v, got %v", types.ErrNotValidator, err)
+	}
+}
+
+func TestRemoveLastBondedValidatorProposal(t *testing.T) {
+	ctx, poaKeeper := poa.MockContext()
+	handler := poa.NewPoaProposalHandler(poaKeeper)
+	joined, _ := poa.MockValidator()
+	jailed, _ := poa.MockValidator()
+	poaKeeper.SetParams(ctx, types.DefaultParams())
+
+	poaKeeper.AppendValidator(ctx, joined)
+	poaKeeper.SetValidatorState(ctx, joined, types.ValidatorStateJoined)
+	poaKeeper.AppendValidator(ctx, jailed)
+	poaKeeper.SetValidatorState(ctx, jailed, types.ValidatorStateJailed)
+	poaKeeper.SetValidatorOutOfSet(ctx, jailed.GetOperator())
+
+	// The joined validator is the last validator in the Tendermint validator set
+	err := handler(ctx, types.NewRemoveValidatorProposal("title", "description", joined.GetOperator()))
+	if err == nil || err.Error() != types.ErrOnlyOneValidator.Error() {
+		t.Errorf("RemoveValidatorProposal with one bonded validator, error should be %v, got %v", types.ErrOnlyOneValidator, err)
+	}
+
+	// The jailed validator can be removed
+	err = handler(ctx, types.NewRemoveValidatorProposal("title", "description", jailed.GetOperator()))
+	if err != nil {
+		t.Errorf("RemoveValidatorProposal should remove a jailed validator, got error %v", err)
+	}
+}
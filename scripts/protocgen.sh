@@ -0,0 +1,13 @@
+#!/usr/bin/env bash
+
+# Generate the Go types and the gRPC services of proto/ into types/pb
+# Requires buf and protoc-gen-gogofaster in the PATH
+set -eo pipefail
+
+cd "$(dirname "$0")/../proto"
+buf generate
+
+# The files are generated under their import path
+cd ..
+cp -r github.com/ltacker/poa/* ./
+rm -rf github.com
@@ -0,0 +1,83 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ltacker/poa/types"
+)
+
+// DecodeStore unmarshals the KVPair's Value to the corresponding poa type
+func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
+	switch {
+	case bytes.Equal(kvA.Key[:1], types.ValidatorsKey):
+		validatorA := types.MustUnmarshalValidator(cdc, kvA.Value)
+		validatorB := types.MustUnmarshalValidator(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", validatorA, validatorB)
+
+	case bytes.Equal(kvA.Key[:1], types.ValidatorsByConsAddrKey),
+		bytes.Equal(kvA.Key[:1], types.ApplicationByConsAddrKey):
+		return fmt.Sprintf("%v\n%v", sdk.ValAddress(kvA.Value), sdk.ValAddress(kvB.Value))
+
+	case bytes.Equal(kvA.Key[:1], types.ValidatorStatesKey):
+		return fmt.Sprintf("%v\n%v", types.ValidatorStateToString(uint16(kvA.Value[0])), types.ValidatorStateToString(uint16(kvB.Value[0])))
+
+	case bytes.Equal(kvA.Key[:1], types.ApplicationPoolKey),
+		bytes.Equal(kvA.Key[:1], types.KickProposalPoolKey):
+		voteA := types.MustUnmarshalVote(cdc, kvA.Value)
+		voteB := types.MustUnmarshalVote(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", voteA, voteB)
+
+	case bytes.Equal(kvA.Key[:1], types.ConsensusVersionKey),
+		bytes.Equal(kvA.Key[:1], types.ValidatorCountKey),
+		bytes.Equal(kvA.Key[:1], types.ValidatorStateCountsKey),
+		bytes.Equal(kvA.Key[:1], types.ValidatorStateHeightsKey),
+		bytes.Equal(kvA.Key[:1], types.ValidatorJoinHeightsKey),
+		bytes.Equal(kvA.Key[:1], types.ProposalOpenHeightsKey),
+		bytes.Equal(kvA.Key[:1], types.NextClosedProposalIDKey):
+		return fmt.Sprintf("%v\n%v", binary.BigEndian.Uint64(kvA.Value), binary.BigEndian.Uint64(kvB.Value))
+
+	case bytes.Equal(kvA.Key[:1], types.ValidatorsOutOfSetKey):
+		return fmt.Sprintf("%v\n%v", sdk.ValAddress(kvA.Key[1:]), sdk.ValAddress(kvB.Key[1:]))
+
+	case bytes.Equal(kvA.Key[:1], types.RemovedValidatorQueueKey):
+		return fmt.Sprintf("%v\n%v", sdk.ValAddress(kvA.Value), sdk.ValAddress(kvB.Value))
+
+	case bytes.Equal(kvA.Key[:1], types.PreviousProposerKey):
+		return fmt.Sprintf("%v\n%v", sdk.ConsAddress(kvA.Value), sdk.ConsAddress(kvB.Value))
+
+	case bytes.Equal(kvA.Key[:1], types.ValidatorRewardsKey):
+		var rewardsA, rewardsB sdk.Coins
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &rewardsA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &rewardsB)
+		return fmt.Sprintf("%v\n%v", rewardsA, rewardsB)
+
+	case bytes.Equal(kvA.Key[:1], types.WithdrawAddrKey):
+		return fmt.Sprintf("%v\n%v", sdk.AccAddress(kvA.Value), sdk.AccAddress(kvB.Value))
+
+	case bytes.Equal(kvA.Key[:1], types.ProposalHistoryKey):
+		closedProposalA := types.MustUnmarshalClosedProposal(cdc, kvA.Value)
+		closedProposalB := types.MustUnmarshalClosedProposal(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", closedProposalA, closedProposalB)
+
+	case bytes.Equal(kvA.Key[:1], types.ParticipationKey):
+		participationA := types.MustUnmarshalParticipation(cdc, kvA.Value)
+		participationB := types.MustUnmarshalParticipation(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", participationA, participationB)
+
+	case bytes.Equal(kvA.Key[:1], types.KickProposalReasonsKey):
+		return fmt.Sprintf("%v\n%v", types.KickReasonToString(uint16(kvA.Value[0])), types.KickReasonToString(uint16(kvB.Value[0])))
+
+	case bytes.Equal(kvA.Key[:1], types.VotesKey):
+		return fmt.Sprintf("option: %d\noption: %d", kvA.Value[0], kvB.Value[0])
+
+	default:
+		panic(fmt.Sprintf("invalid poa key prefix %X", kvA.Key[:1]))
+	}
+}
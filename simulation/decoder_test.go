@@ -0,0 +1,92 @@
+package simulation_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/simulation"
+	"github.com/ltacker/poa/types"
+)
+
+func TestDecodeStore(t *testing.T) {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	ctx, k, storeKey, _ := poa.MockContextWithStoreKey()
+	k.SetParams(ctx, types.DefaultParams())
+
+	// Fill each store of the module
+	validator1, _ := poa.MockValidator()
+	validator2, _ := poa.MockValidator()
+	candidate, _ := poa.MockValidator()
+	k.SetConsensusVersion(ctx, types.ConsensusVersion)
+	k.AppendValidator(ctx, validator1)
+	k.AppendValidator(ctx, validator2)
+	k.SetValidatorOutOfSet(ctx, validator2.GetOperator())
+	k.SetPreviousProposer(ctx, validator1.GetConsAddr())
+	k.SetValidatorRewards(ctx, validator1.GetOperator(), sdk.NewCoins(sdk.NewInt64Coin("stake", 10)))
+	k.SetWithdrawAddress(ctx, validator1.GetOperator(), sdk.AccAddress(validator2.GetOperator()))
+	k.AppendApplication(ctx, candidate)
+	k.AppendKickProposal(ctx, validator2)
+	k.SetKickProposalReason(ctx, validator2.GetOperator(), types.KickReasonMissedVotes)
+	kickProposal, _ := k.GetKickProposal(ctx, validator2.GetOperator())
+	k.AddVote(ctx, types.VoteTypeKickProposal, &kickProposal, validator1.GetOperator(), true)
+	k.QueueRemovedValidator(ctx, candidate)
+	k.RecordVote(ctx, validator1.GetOperator(), types.VoteTypeApplication)
+	application, _ := k.GetApplication(ctx, candidate.GetOperator())
+	k.ArchiveProposal(ctx, types.VoteTypeApplication, application, types.ProposalOutcomeRejected)
+
+	prefixes := [][]byte{
+		types.ValidatorsKey, types.ValidatorsByConsAddrKey, types.ValidatorStatesKey, types.ApplicationPoolKey,
+		types.ApplicationByConsAddrKey, types.KickProposalPoolKey, types.ConsensusVersionKey, types.ValidatorsOutOfSetKey,
+		types.PreviousProposerKey, types.ValidatorRewardsKey, types.WithdrawAddrKey, types.ValidatorStateHeightsKey,
+		types.ProposalHistoryKey, types.NextClosedProposalIDKey, types.ParticipationKey, types.KickProposalReasonsKey, types.VotesKey, types.ValidatorCountKey,
+		types.ValidatorStateCountsKey, types.ValidatorJoinHeightsKey, types.RemovedValidatorQueueKey, types.ProposalOpenHeightsKey,
+	}
+	decoded := make(map[string]bool)
+
+	// Each value of the store can be decoded
+	iterator := ctx.KVStore(storeKey).Iterator(nil, nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		kv := tmkv.Pair{Key: iterator.Key(), Value: iterator.Value()}
+		log := simulation.DecodeStore(cdc, kv, kv)
+		parts := strings.Split(log, "\n")
+		if len(parts) != 2 || parts[0] != parts[1] || parts[0] == "" {
+			t.Errorf("DecodeStore should decode the value of the key %X twice, got %q", kv.Key, log)
+		}
+		decoded[string(kv.Key[:1])] = true
+	}
+	for _, prefix := range prefixes {
+		if !decoded[string(prefix)] {
+			t.Errorf("The store should contain a value with the prefix %X", prefix)
+		}
+	}
+
+	// The decoded values are readable
+	kv := tmkv.Pair{Key: types.GetValidatorStateKey(validator1.GetOperator()), Value: []byte{byte(types.ValidatorStateJoining)}}
+	if log := simulation.DecodeStore(cdc, kv, kv); log != "joining\njoining" {
+		t.Errorf("DecodeStore should decode the name of the state, got %q", log)
+	}
+	kv = tmkv.Pair{Key: types.GetWithdrawAddrKey(validator1.GetOperator()), Value: validator2.GetOperator()}
+	expected := fmt.Sprintf("%v\n%v", sdk.AccAddress(validator2.GetOperator()), sdk.AccAddress(validator2.GetOperator()))
+	if log := simulation.DecodeStore(cdc, kv, kv); log != expected {
+		t.Errorf("DecodeStore should decode the withdraw address, got %q", log)
+	}
+
+	// An unknown prefix cannot be decoded
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("DecodeStore should panic with an unknown prefix")
+		}
+	}()
+	kv = tmkv.Pair{Key: []byte{0x99}, Value: []byte{0x99}}
+	simulation.DecodeStore(cdc, kv, kv)
+}
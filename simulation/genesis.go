@@ -0,0 +1,126 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/ltacker/poa/types"
+)
+
+// Simulation parameter constants
+const (
+	MaxValidators  = "max_validators"
+	Quorum         = "quorum"
+	BlockReward    = "block_reward"
+	ProposerBonus  = "proposer_bonus"
+	MaxMissedVotes = "max_missed_votes"
+)
+
+// GenMaxValidators randomized MaxValidators
+func GenMaxValidators(r *rand.Rand) uint16 {
+	return uint16(r.Intn(250) + 1)
+}
+
+// GenQuorum randomized Quorum, the applications are directly approved with a quorum of 0
+func GenQuorum(r *rand.Rand) uint16 {
+	return uint16(r.Intn(101))
+}
+
+// GenBlockReward randomized BlockReward, no coins are minted half of the time
+func GenBlockReward(r *rand.Rand) sdk.Coins {
+	if r.Intn(2) == 0 {
+		return types.DefaultBlockReward
+	}
+	return sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, r.Int63n(1000)+1))
+}
+
+// GenProposerBonus randomized ProposerBonus
+func GenProposerBonus(r *rand.Rand) sdk.Dec {
+	return sdk.NewDecWithPrec(int64(r.Intn(101)), 2)
+}
+
+// GenMaxMissedVotes randomized MaxMissedVotes, the automatic kick proposals are disabled with 0
+func GenMaxMissedVotes(r *rand.Rand) uint64 {
+	return uint64(r.Intn(10))
+}
+
+// RandomizedGenState generates a random GenesisState for poa
+// The first accounts of the simulation are the genesis validators, their account key is also their consensus key
+func RandomizedGenState(simState *module.SimulationState) {
+	var maxValidators uint16
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxValidators, &maxValidators, simState.Rand,
+		func(r *rand.Rand) { maxValidators = GenMaxValidators(r) },
+	)
+
+	var quorum uint16
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, Quorum, &quorum, simState.Rand,
+		func(r *rand.Rand) { quorum = GenQuorum(r) },
+	)
+
+	var blockReward sdk.Coins
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, BlockReward, &blockReward, simState.Rand,
+		func(r *rand.Rand) { blockReward = GenBlockReward(r) },
+	)
+
+	var proposerBonus sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, ProposerBonus, &proposerBonus, simState.Rand,
+		func(r *rand.Rand) { proposerBonus = GenProposerBonus(r) },
+	)
+
+	var maxMissedVotes uint64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxMissedVotes, &maxMissedVotes, simState.Rand,
+		func(r *rand.Rand) { maxMissedVotes = GenMaxMissedVotes(r) },
+	)
+
+	params := types.NewParams(maxValidators, quorum).
+		WithRewards(blockReward, proposerBonus).
+		WithMaxMissedVotes(maxMissedVotes)
+
+	// The chain needs at least one validator and cannot start with more than the max
+	numValidators := int(simState.NumBonded)
+	if numValidators > int(maxValidators) {
+		numValidators = int(maxValidators)
+	}
+	if numValidators > len(simState.Accounts) {
+		numValidators = len(simState.Accounts)
+	}
+	if numValidators == 0 && len(simState.Accounts) > 0 {
+		numValidators = 1
+	}
+
+	validators := make([]types.Validator, numValidators)
+	for i := range validators {
+		account := simState.Accounts[i]
+		validators[i] = types.NewValidator(
+			sdk.ValAddress(account.Address),
+			account.PubKey,
+			RandomDescription(simState.Rand),
+		)
+	}
+
+	poaGenesis := types.NewGenesisState(params, validators)
+
+	fmt.Printf("Selected randomly generated poa parameters:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, poaGenesis.Params))
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(poaGenesis)
+}
+
+// RandomDescription generates a random validator description
+func RandomDescription(r *rand.Rand) types.Description {
+	return types.NewDescription(
+		simulation.RandStringOfLength(r, 10),
+		simulation.RandStringOfLength(r, 10),
+		simulation.RandStringOfLength(r, 10),
+		simulation.RandStringOfLength(r, 10),
+		simulation.RandStringOfLength(r, 10),
+	)
+}
@@ -0,0 +1,48 @@
+package simulation_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	sim "github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/ltacker/poa/simulation"
+	"github.com/ltacker/poa/types"
+)
+
+func TestRandomizedGenState(t *testing.T) {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+
+	for seed := int64(0); seed < 20; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		simState := module.SimulationState{
+			AppParams: make(sim.AppParams),
+			Cdc:       cdc,
+			Rand:      r,
+			Accounts:  sim.RandomAccounts(r, 300),
+			NumBonded: int64(r.Intn(300)),
+			GenState:  make(map[string]json.RawMessage),
+		}
+		simulation.RandomizedGenState(&simState)
+
+		var genState types.GenesisState
+		cdc.MustUnmarshalJSON(simState.GenState[types.ModuleName], &genState)
+		if err := types.ValidateGenesis(genState); err != nil {
+			t.Errorf("The randomized genesis state with seed %v should be valid, got error %v", seed, err)
+		}
+		if len(genState.Validators) == 0 {
+			t.Errorf("The randomized genesis state with seed %v should contain at least one validator", seed)
+		}
+
+		// The genesis validators are accounts of the simulation
+		for i, validator := range genState.Validators {
+			if !validator.GetOperator().Equals(simState.Accounts[i].Address) {
+				t.Errorf("The validator %v should be the account %v, got %v", i, simState.Accounts[i].Address, validator.GetOperator())
+			}
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/types"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgSubmitApplication = "op_weight_msg_submit_application"
+	OpWeightMsgProposeKick       = "op_weight_msg_propose_kick"
+	OpWeightMsgVote              = "op_weight_msg_vote"
+	OpWeightMsgLeaveValidatorSet = "op_weight_msg_leave_validator_set"
+)
+
+// Default simulation operation weights
+const (
+	DefaultWeightMsgSubmitApplication int = 100
+	DefaultWeightMsgProposeKick       int = 20
+	DefaultWeightMsgVote              int = 100
+	DefaultWeightMsgLeaveValidatorSet int = 5
+)
+
+// WeightedOperations returns all the operations from the module with their respective weights
+// The preconditions checked by the handler are verified before delivering a message,
+// any error of the handler fails the simulation, including a panic
+func WeightedOperations(
+	appParams simulation.AppParams, cdc *codec.Codec, ak types.AccountKeeper, k keeper.Keeper,
+) simulation.WeightedOperations {
+
+	var (
+		weightMsgSubmitApplication int
+		weightMsgProposeKick       int
+		weightMsgVote              int
+		weightMsgLeaveValidatorSet int
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgSubmitApplication, &weightMsgSubmitApplication, nil,
+		func(_ *rand.Rand) {
+			weightMsgSubmitApplication = DefaultWeightMsgSubmitApplication
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgProposeKick, &weightMsgProposeKick, nil,
+		func(_ *rand.Rand) {
+			weightMsgProposeKick = DefaultWeightMsgProposeKick
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgVote, &weightMsgVote, nil,
+		func(_ *rand.Rand) {
+			weightMsgVote = DefaultWeightMsgVote
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgLeaveValidatorSet, &weightMsgLeaveValidatorSet, nil,
+		func(_ *rand.Rand) {
+			weightMsgLeaveValidatorSet = DefaultWeightMsgLeaveValidatorSet
+		},
+	)
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(
+			weightMsgSubmitApplication,
+			SimulateMsgSubmitApplication(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgProposeKick,
+			SimulateMsgProposeKick(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgVote,
+			SimulateMsgVote(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgLeaveValidatorSet,
+			SimulateMsgLeaveValidatorSet(ak, k),
+		),
+	}
+}
+
+// SimulateMsgSubmitApplication generates a MsgSubmitApplication from a random account
+// The consensus key of the candidate is its account key
+func SimulateMsgSubmitApplication(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		simAccount, _ := simulation.RandomAcc(r, accs)
+		candidate := types.NewValidator(sdk.ValAddress(simAccount.Address), simAccount.PubKey, RandomDescription(r))
+
+		// The max number of validators must not be reached
+		if k.GetValidatorCount(ctx) >= uint64(k.MaxValidators(ctx)) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// The candidate must not be a validator or already applying
+		if _, found := k.GetValidator(ctx, candidate.GetOperator()); found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		if _, found := k.GetValidatorByConsAddr(ctx, candidate.GetConsAddr()); found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		if k.Quorum(ctx) > 0 {
+			if _, found := k.GetApplication(ctx, candidate.GetOperator()); found {
+				return simulation.NoOpMsg(types.ModuleName), nil, nil
+			}
+			if _, found := k.GetApplicationByConsAddr(ctx, candidate.GetConsAddr()); found {
+				return simulation.NoOpMsg(types.ModuleName), nil, nil
+			}
+		}
+
+		msg := types.NewMsgSubmitApplication(candidate)
+
+		return deliverMsg(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+// SimulateMsgProposeKick generates a MsgProposeKick from a random validator against another random validator
+func SimulateMsgProposeKick(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		proposer, simAccount, found := randomValidator(r, ctx, k, accs)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		candidate, _, _ := randomValidator(r, ctx, k, accs)
+		if candidate.GetOperator().Equals(proposer.GetOperator()) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// The candidate must not be leaving or already in a kick proposal
+		// A candidate without state is not skipped, the handler panics
+		state, _ := k.GetValidatorState(ctx, candidate.GetOperator())
+		if state == types.ValidatorStateLeaving {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		if k.Quorum(ctx) > 0 {
+			if _, found := k.GetKickProposal(ctx, candidate.GetOperator()); found {
+				return simulation.NoOpMsg(types.ModuleName), nil, nil
+			}
+		}
+
+		msg := types.NewMsgProposeKick(candidate.GetOperator(), proposer.GetOperator())
+
+		return deliverMsg(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+// SimulateMsgVote generates a MsgVote from a random validator on a random application or kick proposal
+func SimulateMsgVote(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		voteType := types.VoteTypeApplication
+		proposals := k.GetAllApplications(ctx)
+		if r.Intn(2) == 0 {
+			voteType = types.VoteTypeKickProposal
+			proposals = k.GetAllKickProposals(ctx)
+		}
+		if len(proposals) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		proposal := proposals[r.Intn(len(proposals))]
+		candidateAddr := proposal.GetSubject().GetOperator()
+
+		voter, simAccount, found := randomValidator(r, ctx, k, accs)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		if k.HasVoted(ctx, voteType, candidateAddr, voter.GetOperator()) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		switch voteType {
+		case types.VoteTypeApplication:
+			// No application can be approved if the max number of validators is reached
+			if k.GetValidatorCount(ctx) >= uint64(k.MaxValidators(ctx)) {
+				return simulation.NoOpMsg(types.ModuleName), nil, nil
+			}
+		case types.VoteTypeKickProposal:
+			// The candidate of a kick proposal cannot vote
+			if voter.GetOperator().Equals(candidateAddr) {
+				return simulation.NoOpMsg(types.ModuleName), nil, nil
+			}
+		}
+
+		msg := types.NewMsgVote(voteType, voter.GetOperator(), candidateAddr, r.Intn(2) == 0)
+
+		return deliverMsg(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+// SimulateMsgLeaveValidatorSet generates a MsgLeaveValidatorSet from a random validator
+func SimulateMsgLeaveValidatorSet(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		validator, simAccount, found := randomValidator(r, ctx, k, accs)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// The last bonded validator cannot leave the validator set
+		if k.IsLastBondedValidator(ctx, validator.GetOperator()) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgLeaveValidatorSet(validator.GetOperator())
+
+		return deliverMsg(r, app, ctx, ak, simAccount, msg, chainID)
+	}
+}
+
+// Get a random validator and its simulation account
+// found is false if there is no validator or if the operator is not an account of the simulation
+func randomValidator(r *rand.Rand, ctx sdk.Context, k keeper.Keeper, accs []simulation.Account) (types.Validator, simulation.Account, bool) {
+	validators := k.GetAllValidators(ctx)
+	if len(validators) == 0 {
+		return types.Validator{}, simulation.Account{}, false
+	}
+
+	validator := validators[r.Intn(len(validators))]
+	simAccount, found := simulation.FindAccount(accs, sdk.AccAddress(validator.GetOperator()))
+
+	return validator, simAccount, found
+}
+
+// Sign a message with a simulation account paying random fees and deliver it
+func deliverMsg(
+	r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, ak types.AccountKeeper,
+	simAccount simulation.Account, msg sdk.Msg, chainID string,
+) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+	account := ak.GetAccount(ctx, simAccount.Address)
+	if account == nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, nil
+	}
+
+	fees, err := simulation.RandomFees(r, ctx, account.SpendableCoins(ctx.BlockTime()))
+	if err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	tx := helpers.GenTx(
+		[]sdk.Msg{msg},
+		fees,
+		helpers.DefaultGenTxGas,
+		chainID,
+		[]uint64{account.GetAccountNumber()},
+		[]uint64{account.GetSequence()},
+		simAccount.PrivKey,
+	)
+
+	if _, _, err := app.Deliver(tx); err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	return simulation.NewOperationMsg(msg, true, ""), nil, nil
+}
@@ -0,0 +1,37 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/ltacker/poa/types"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simulation.ParamChange {
+	return []simulation.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxValidators),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%d", GenMaxValidators(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyQuorum),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%d", GenQuorum(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyProposerBonus),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%s\"", GenProposerBonus(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxMissedVotes),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenMaxMissedVotes(r))
+			},
+		),
+	}
+}
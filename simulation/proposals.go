@@ -0,0 +1,75 @@
+package simulation
+
+import (
+	"math/rand"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/ltacker/poa/keeper"
+	"github.com/ltacker/poa/types"
+)
+
+// Simulation operation weights constants of the proposals
+const (
+	OpWeightSubmitAddValidatorProposal    = "op_weight_submit_add_validator_proposal"
+	OpWeightSubmitRemoveValidatorProposal = "op_weight_submit_remove_validator_proposal"
+)
+
+// Default simulation operation weights of the proposals
+const (
+	DefaultWeightAddValidatorProposal    int = 5
+	DefaultWeightRemoveValidatorProposal int = 5
+)
+
+// ProposalContents defines the module weighted proposals' contents
+func ProposalContents(k keeper.Keeper) []simulation.WeightedProposalContent {
+	return []simulation.WeightedProposalContent{
+		{
+			AppParamsKey:       OpWeightSubmitAddValidatorProposal,
+			DefaultWeight:      DefaultWeightAddValidatorProposal,
+			ContentSimulatorFn: SimulateAddValidatorProposalContent(k),
+		},
+		{
+			AppParamsKey:       OpWeightSubmitRemoveValidatorProposal,
+			DefaultWeight:      DefaultWeightRemoveValidatorProposal,
+			ContentSimulatorFn: SimulateRemoveValidatorProposalContent(k),
+		},
+	}
+}
+
+// SimulateAddValidatorProposalContent generates a proposal adding a random account to the validator set
+func SimulateAddValidatorProposalContent(k keeper.Keeper) simulation.ContentSimulatorFn {
+	return func(r *rand.Rand, ctx sdk.Context, accs []simulation.Account) govtypes.Content {
+		simAccount, _ := simulation.RandomAcc(r, accs)
+		validator := types.NewValidator(sdk.ValAddress(simAccount.Address), simAccount.PubKey, RandomDescription(r))
+
+		if _, found := k.GetValidator(ctx, validator.GetOperator()); found {
+			return nil
+		}
+
+		return types.NewAddValidatorProposal(
+			simulation.RandStringOfLength(r, 10),
+			simulation.RandStringOfLength(r, 100),
+			validator,
+		)
+	}
+}
+
+// SimulateRemoveValidatorProposalContent generates a proposal removing a random validator from the validator set
+func SimulateRemoveValidatorProposalContent(k keeper.Keeper) simulation.ContentSimulatorFn {
+	return func(r *rand.Rand, ctx sdk.Context, accs []simulation.Account) govtypes.Content {
+		validators := k.GetAllValidators(ctx)
+		if len(validators) <= 1 {
+			return nil
+		}
+		validator := validators[r.Intn(len(validators))]
+
+		return types.NewRemoveValidatorProposal(
+			simulation.RandStringOfLength(r, 10),
+			simulation.RandStringOfLength(r, 100),
+			validator.GetOperator(),
+		)
+	}
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/ltacker/poa/keeper"
 	"github.com/ltacker/poa/types"
 )
@@ -20,17 +23,52 @@ import (
 
 // Context and keeper used for mocking purpose
 func MockContext() (sdk.Context, keeper.Keeper) {
+	ctx, poaKeeper, _, _ := MockContextWithStoreKey()
+	return ctx, poaKeeper
+}
+
+// Context and keeper used for mocking purpose, the poa store key and param subspace are returned for raw store accesses
+func MockContextWithStoreKey() (sdk.Context, keeper.Keeper, sdk.StoreKey, params.Subspace) {
+	ctx, poaKeeper, _, storeKey, paramspace := mockContext(false)
+	return ctx, poaKeeper, storeKey, paramspace
+}
+
+// Context and keeper distributing rewards used for mocking purpose, the supply keeper is returned to manage the fees
+func MockContextWithSupply() (sdk.Context, keeper.Keeper, supply.Keeper) {
+	ctx, poaKeeper, supplyKeeper, _, _ := mockContext(true)
+	return ctx, poaKeeper, supplyKeeper
+}
+
+func mockContext(withSupply bool) (sdk.Context, keeper.Keeper, supply.Keeper, sdk.StoreKey, params.Subspace) {
 	// Store keys
-	keys := sdk.NewKVStoreKeys(types.StoreKey, params.StoreKey)
+	keys := sdk.NewKVStoreKeys(types.StoreKey, params.StoreKey, auth.StoreKey, supply.StoreKey)
 	tKeys := sdk.NewTransientStoreKeys(params.TStoreKey)
 
 	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
 
 	// Create the params keeper
 	paramsKeeper := params.NewKeeper(cdc, keys[params.StoreKey], tKeys[params.TStoreKey])
 
+	// Create the keepers managing the rewards
+	maccPerms := map[string][]string{
+		auth.FeeCollectorName: nil,
+		types.ModuleName:      {supply.Minter},
+	}
+	accountKeeper := auth.NewAccountKeeper(cdc, keys[auth.StoreKey], paramsKeeper.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, paramsKeeper.Subspace(bank.DefaultParamspace), map[string]bool{})
+	supplyKeeper := supply.NewKeeper(cdc, keys[supply.StoreKey], accountKeeper, bankKeeper, maccPerms)
+
 	// Create a poa keeper
-	poaKeeper := keeper.NewKeeper(cdc, keys[types.StoreKey], paramsKeeper.Subspace(types.ModuleName))
+	var poaKeeper keeper.Keeper
+	if withSupply {
+		poaKeeper = keeper.NewKeeper(cdc, keys[types.StoreKey], paramsKeeper.Subspace(types.ModuleName), supplyKeeper, auth.FeeCollectorName)
+	} else {
+		poaKeeper = keeper.NewKeeper(cdc, keys[types.StoreKey], paramsKeeper.Subspace(types.ModuleName), nil, "")
+	}
 
 	// Create multiStore in memory
 	db := dbm.NewMemDB()
@@ -39,13 +77,23 @@ func MockContext() (sdk.Context, keeper.Keeper) {
 	// Mount stores
 	cms.MountStoreWithDB(keys[types.StoreKey], sdk.StoreTypeIAVL, db)
 	cms.MountStoreWithDB(keys[params.StoreKey], sdk.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(keys[auth.StoreKey], sdk.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(keys[supply.StoreKey], sdk.StoreTypeIAVL, db)
 	cms.MountStoreWithDB(tKeys[params.TStoreKey], sdk.StoreTypeTransient, db)
 	cms.LoadLatestVersion()
 
 	// Create context
 	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
 
-	return ctx, poaKeeper
+	if withSupply {
+		accountKeeper.SetParams(ctx, auth.DefaultParams())
+		bankKeeper.SetSendEnabled(ctx, true)
+		supplyKeeper.SetSupply(ctx, supply.NewSupply(sdk.NewCoins()))
+	}
+
+	paramspace, _ := paramsKeeper.GetSubspace(types.ModuleName)
+
+	return ctx, poaKeeper, supplyKeeper, keys[types.StoreKey], paramspace
 }
 
 // Create a validator for test
@@ -8,8 +8,13 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSubmitApplication{}, "poa/MsgSubmitApplication", nil)
 	cdc.RegisterConcrete(MsgVote{}, "poa/MsgVote", nil)
+	cdc.RegisterConcrete(MsgVoteBatch{}, "poa/MsgVoteBatch", nil)
 	cdc.RegisterConcrete(MsgProposeKick{}, "poa/MsgProposeKick", nil)
 	cdc.RegisterConcrete(MsgLeaveValidatorSet{}, "poa/MsgLeaveValidatorSet", nil)
+	cdc.RegisterConcrete(MsgSetWithdrawAddress{}, "poa/MsgSetWithdrawAddress", nil)
+	cdc.RegisterConcrete(MsgWithdrawRewards{}, "poa/MsgWithdrawRewards", nil)
+	cdc.RegisterConcrete(AddValidatorProposal{}, "poa/AddValidatorProposal", nil)
+	cdc.RegisterConcrete(RemoveValidatorProposal{}, "poa/RemoveValidatorProposal", nil)
 }
 
 // ModuleCdc defines the module codec
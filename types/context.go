@@ -0,0 +1,22 @@
+package types
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Key of the sdk.Context in the context of a gRPC call
+type sdkContextKey struct{}
+
+// WrapSDKContext returns a context carrying the sdk.Context to the Msg service and the Query service
+// The server serving the services must wrap the context of each call, for example in an interceptor
+func WrapSDKContext(ctx sdk.Context) context.Context {
+	return context.WithValue(ctx.Context(), sdkContextKey{}, ctx)
+}
+
+// UnwrapSDKContext returns the sdk.Context carried by the context of a gRPC call
+// It panics if the context doesn't carry an sdk.Context
+func UnwrapSDKContext(goCtx context.Context) sdk.Context {
+	return goCtx.Value(sdkContextKey{}).(sdk.Context)
+}
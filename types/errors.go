@@ -25,4 +25,6 @@ var (
 	ErrVoterIsCandidate      = sdkerrors.Register(ModuleName, 18, "the voter cannot be the candidate")
 	ErrProposerIsCandidate   = sdkerrors.Register(ModuleName, 19, "the proposer cannot be the candidate")
 	ErrOnlyOneValidator      = sdkerrors.Register(ModuleName, 20, "there is only one validator in the validator set")
+	ErrInvalidWithdrawMsg    = sdkerrors.Register(ModuleName, 21, "the withdraw message is invalid")
+	ErrNoRewards             = sdkerrors.Register(ModuleName, 22, "the validator has no rewards")
 )
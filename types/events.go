@@ -2,22 +2,40 @@ package types
 
 // poa module event types
 const (
-	EventTypeSubmitApplication   = "submit_application"
-	EventTypeAppendValidator     = "append_validator"
-	EventTypeProposeKick         = "propose_kick"
-	EventTypeKickValidator       = "kick_validator"
-	EventTypeLeaveValidatorSet   = "leave_validator_set"
-	EventTypeApproveApplication  = "approve_application"
-	EventTypeRejectApplication   = "reject_application"
-	EventTypeRejectValidator     = "reject_validator"
-	EventTypeApproveKickProposal = "approve_kick_proposal"
-	EventTypeRejectKickProposal  = "reject_kick_proposal"
-	EventTypeKeepValidator       = "keep_validator"
+	EventTypeSubmitApplication     = "submit_application"
+	EventTypeAppendValidator       = "append_validator"
+	EventTypeProposeKick           = "propose_kick"
+	EventTypeKickValidator         = "kick_validator"
+	EventTypeLeaveValidatorSet     = "leave_validator_set"
+	EventTypeApproveApplication    = "approve_application"
+	EventTypeRejectApplication     = "reject_application"
+	EventTypeRejectValidator       = "reject_validator"
+	EventTypeApproveKickProposal   = "approve_kick_proposal"
+	EventTypeRejectKickProposal    = "reject_kick_proposal"
+	EventTypeKeepValidator         = "keep_validator"
+	EventTypeRewards               = "rewards"
+	EventTypeWithdrawRewards       = "withdraw_rewards"
+	EventTypeSetWithdrawAddress    = "set_withdraw_address"
+	EventTypeValidatorJoined       = "validator_joined"
+	EventTypeValidatorRemoved      = "validator_removed"
+	EventTypeRemoveExcessValidator = "remove_excess_validator"
 
 	AttributeKeyValidator = "validator"
 	AttributeKeyCandidate = "candidate"
 	AttributeKeyVoter     = "voter"
 	AttributeKeyProposer  = "proposer"
+	AttributeKeyReason    = "reason"
+
+	AttributeKeyApprovals         = "approvals"
+	AttributeKeyTotal             = "total"
+	AttributeKeyRequiredApprovals = "required_approvals"
+	AttributeKeyPoolSize          = "pool_size"
+	AttributeKeyMaxValidators     = "max_validators"
+
+	AttributeKeyConsensusPubkey = "consensus_pubkey"
+	AttributeKeyPower           = "power"
+
+	AttributeKeyWithdrawAddress = "withdraw_address"
 
 	AttributeValueCategory = ModuleName
 )
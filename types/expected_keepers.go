@@ -2,13 +2,42 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
 )
 
 // ParamSubspace defines the expected Subspace interface
 type ParamSubspace interface {
 	WithKeyTable(table params.KeyTable) params.Subspace
 	Get(ctx sdk.Context, key []byte, ptr interface{})
+	Has(ctx sdk.Context, key []byte) bool
+	Set(ctx sdk.Context, key []byte, value interface{})
 	GetParamSet(ctx sdk.Context, ps params.ParamSet)
 	SetParamSet(ctx sdk.Context, ps params.ParamSet)
 }
+
+// SupplyKeeper defines the expected supply keeper used to distribute the rewards
+type SupplyKeeper interface {
+	GetModuleAddress(name string) sdk.AccAddress
+	GetModuleAccount(ctx sdk.Context, name string) supplyexported.ModuleAccountI
+
+	MintCoins(ctx sdk.Context, name string, amt sdk.Coins) error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule string, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+}
+
+// AccountKeeper defines the expected account keeper used to sign the messages of the simulation
+type AccountKeeper interface {
+	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authexported.Account
+}
+
+// PoaHooks event hooks for the validator set changes of the poa module
+type PoaHooks interface {
+	AfterApplicationSubmitted(ctx sdk.Context, candidateAddr sdk.ValAddress)                      // Must be called when a new application is submitted
+	AfterValidatorAppended(ctx sdk.Context, valAddr sdk.ValAddress)                               // Must be called when a validator is appended to the validator set
+	AfterValidatorJoined(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress)       // Must be called when a validator joins the Tendermint validator set
+	AfterKickProposed(ctx sdk.Context, candidateAddr sdk.ValAddress, proposerAddr sdk.ValAddress) // Must be called when a new kick proposal is created
+	BeforeValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress)     // Must be called before a validator is removed from the store
+	AfterValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress)      // Must be called once a removed validator is no longer in the last commit
+}
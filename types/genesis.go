@@ -1,11 +1,63 @@
 package types
 
-import "fmt"
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
 
 // GenesisState - all poa state that must be provided at genesis
+// A validator without an entry in ValidatorStates is joined
+// The rewards are held by the poa module account, their sum must match its balance
 type GenesisState struct {
-	Params     Params      `json:"params"`
-	Validators []Validator `json:"validators"`
+	Params            Params                    `json:"params"`
+	Validators        []Validator               `json:"validators"`
+	ValidatorStates   []GenesisValidatorState   `json:"validator_states,omitempty"`
+	Rewards           []GenesisValidatorRewards `json:"rewards,omitempty"`
+	WithdrawAddresses []GenesisWithdrawAddress  `json:"withdraw_addresses,omitempty"`
+}
+
+// GenesisValidatorState - state of a genesis validator
+// The state is joined, jailed or leaving, a jailed or leaving validator is not part of Tendermint validator set
+type GenesisValidatorState struct {
+	OperatorAddress sdk.ValAddress `json:"operator_address"`
+	State           uint16         `json:"state"`
+}
+
+// NewGenesisValidatorState creates a new GenesisValidatorState object
+func NewGenesisValidatorState(operatorAddress sdk.ValAddress, state uint16) GenesisValidatorState {
+	return GenesisValidatorState{
+		OperatorAddress: operatorAddress,
+		State:           state,
+	}
+}
+
+// GenesisValidatorRewards - rewards accumulated by a genesis validator
+type GenesisValidatorRewards struct {
+	OperatorAddress sdk.ValAddress `json:"operator_address"`
+	Rewards         sdk.Coins      `json:"rewards"`
+}
+
+// NewGenesisValidatorRewards creates a new GenesisValidatorRewards object
+func NewGenesisValidatorRewards(operatorAddress sdk.ValAddress, rewards sdk.Coins) GenesisValidatorRewards {
+	return GenesisValidatorRewards{
+		OperatorAddress: operatorAddress,
+		Rewards:         rewards,
+	}
+}
+
+// GenesisWithdrawAddress - address receiving the rewards of a genesis validator
+type GenesisWithdrawAddress struct {
+	OperatorAddress sdk.ValAddress `json:"operator_address"`
+	WithdrawAddress sdk.AccAddress `json:"withdraw_address"`
+}
+
+// NewGenesisWithdrawAddress creates a new GenesisWithdrawAddress object
+func NewGenesisWithdrawAddress(operatorAddress sdk.ValAddress, withdrawAddress sdk.AccAddress) GenesisWithdrawAddress {
+	return GenesisWithdrawAddress{
+		OperatorAddress: operatorAddress,
+		WithdrawAddress: withdrawAddress,
+	}
 }
 
 // NewGenesisState creates a new GenesisState object
@@ -28,23 +80,122 @@ func ValidateGenesis(data GenesisState) error {
 	if err := validateGenesisStateValidators(data.Validators); err != nil {
 		return err
 	}
+	if err := validateGenesisStateValidatorStates(data.Validators, data.ValidatorStates); err != nil {
+		return err
+	}
+	if err := validateGenesisStateRewards(data.Validators, data.Rewards); err != nil {
+		return err
+	}
+	if err := validateGenesisStateWithdrawAddresses(data.Validators, data.WithdrawAddresses); err != nil {
+		return err
+	}
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+	if len(data.Validators) > int(data.Params.MaxValidators) {
+		return fmt.Errorf("the genesis state contains %v validators, the maximum is %v", len(data.Validators), data.Params.MaxValidators)
+	}
 
-	return data.Params.Validate()
+	return nil
 }
 
 // Validate the validator set in genesis
 func validateGenesisStateValidators(validators []Validator) (err error) {
 	addrMap := make(map[string]bool, len(validators))
+	operatorMap := make(map[string]bool, len(validators))
 
 	for i := 0; i < len(validators); i++ {
 		val := validators[i]
-		strKey := string(val.GetConsPubKey().Bytes())
+		if err := val.CheckValid(); err != nil {
+			return fmt.Errorf("invalid validator in genesis state: moniker %v: %v", val.Description.Moniker, err)
+		}
 
+		strKey := string(val.GetConsPubKey().Bytes())
 		if _, ok := addrMap[strKey]; ok {
 			return fmt.Errorf("duplicate validator in genesis state: moniker %v, address %v", val.Description.Moniker, val.GetConsAddr())
 		}
+		if _, ok := operatorMap[val.GetOperator().String()]; ok {
+			return fmt.Errorf("duplicate validator in genesis state: moniker %v, operator %v", val.Description.Moniker, val.GetOperator())
+		}
 
 		addrMap[strKey] = true
+		operatorMap[val.GetOperator().String()] = true
 	}
 	return
 }
+
+// Validate the states of the validators in genesis
+func validateGenesisStateValidatorStates(validators []Validator, states []GenesisValidatorState) error {
+	operatorMap := genesisOperators(validators)
+
+	stateMap := make(map[string]bool, len(states))
+	for _, state := range states {
+		if !operatorMap[state.OperatorAddress.String()] {
+			return fmt.Errorf("state of an unknown validator in genesis state: operator %v", state.OperatorAddress)
+		}
+		if stateMap[state.OperatorAddress.String()] {
+			return fmt.Errorf("duplicate validator state in genesis state: operator %v", state.OperatorAddress)
+		}
+		if state.State != ValidatorStateJoined && state.State != ValidatorStateJailed && state.State != ValidatorStateLeaving {
+			return fmt.Errorf("invalid validator state in genesis state: operator %v, state %v", state.OperatorAddress, state.State)
+		}
+
+		stateMap[state.OperatorAddress.String()] = true
+	}
+
+	return nil
+}
+
+// Validate the rewards of the validators in genesis
+func validateGenesisStateRewards(validators []Validator, rewards []GenesisValidatorRewards) error {
+	operatorMap := genesisOperators(validators)
+
+	rewardsMap := make(map[string]bool, len(rewards))
+	for _, entry := range rewards {
+		if !operatorMap[entry.OperatorAddress.String()] {
+			return fmt.Errorf("rewards of an unknown validator in genesis state: operator %v", entry.OperatorAddress)
+		}
+		if rewardsMap[entry.OperatorAddress.String()] {
+			return fmt.Errorf("duplicate validator rewards in genesis state: operator %v", entry.OperatorAddress)
+		}
+		if !entry.Rewards.IsValid() || entry.Rewards.IsZero() {
+			return fmt.Errorf("invalid validator rewards in genesis state: operator %v, rewards %v", entry.OperatorAddress, entry.Rewards)
+		}
+
+		rewardsMap[entry.OperatorAddress.String()] = true
+	}
+
+	return nil
+}
+
+// Validate the withdraw addresses of the validators in genesis
+func validateGenesisStateWithdrawAddresses(validators []Validator, withdrawAddresses []GenesisWithdrawAddress) error {
+	operatorMap := genesisOperators(validators)
+
+	withdrawMap := make(map[string]bool, len(withdrawAddresses))
+	for _, entry := range withdrawAddresses {
+		if !operatorMap[entry.OperatorAddress.String()] {
+			return fmt.Errorf("withdraw address of an unknown validator in genesis state: operator %v", entry.OperatorAddress)
+		}
+		if withdrawMap[entry.OperatorAddress.String()] {
+			return fmt.Errorf("duplicate withdraw address in genesis state: operator %v", entry.OperatorAddress)
+		}
+		if entry.WithdrawAddress.Empty() {
+			return fmt.Errorf("empty withdraw address in genesis state: operator %v", entry.OperatorAddress)
+		}
+
+		withdrawMap[entry.OperatorAddress.String()] = true
+	}
+
+	return nil
+}
+
+// Get the operator addresses of the validators in genesis
+func genesisOperators(validators []Validator) map[string]bool {
+	operatorMap := make(map[string]bool, len(validators))
+	for _, validator := range validators {
+		operatorMap[validator.GetOperator().String()] = true
+	}
+
+	return operatorMap
+}
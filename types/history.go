@@ -0,0 +1,73 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Outcomes of a closed proposal
+const (
+	ProposalOutcomeApproved  = "approved"  // The quorum has been reached to approve the proposal, or a governance proposal enforced it
+	ProposalOutcomeRejected  = "rejected"  // The quorum has been reached to reject the proposal
+	ProposalOutcomeExpired   = "expired"   // The subject of the proposal has been removed before a decision
+	ProposalOutcomeWithdrawn = "withdrawn" // The subject of the proposal left the validator set by itself
+)
+
+// Check if an outcome is valid
+func IsValidProposalOutcome(outcome string) bool {
+	switch outcome {
+	case ProposalOutcomeApproved, ProposalOutcomeRejected, ProposalOutcomeExpired, ProposalOutcomeWithdrawn:
+		return true
+	default:
+		return false
+	}
+}
+
+// Choice of a voter in an application or a kick proposal
+// Unknown is set for a vote migrated from a store that didn't record the approvers, Approve is then meaningless
+type VoterChoice struct {
+	Voter   sdk.ValAddress `json:"voter" yaml:"voter"`
+	Approve bool           `json:"approve" yaml:"approve"`
+	Unknown bool           `json:"unknown,omitempty" yaml:"unknown,omitempty"`
+}
+
+// Application or kick proposal archived when it is closed
+// ProposalType is VoteTypeApplication or VoteTypeKickProposal
+type ClosedProposal struct {
+	ID            uint64        `json:"id" yaml:"id"`
+	ProposalType  uint16        `json:"proposal_type" yaml:"proposal_type"`
+	Subject       Validator     `json:"subject" yaml:"subject"`
+	Approvals     uint64        `json:"approvals" yaml:"approvals"`
+	Total         uint64        `json:"total" yaml:"total"`
+	Votes         []VoterChoice `json:"votes" yaml:"votes"`
+	Outcome       string        `json:"outcome" yaml:"outcome"`
+	ClosingHeight int64         `json:"closing_height" yaml:"closing_height"`
+}
+
+// Create the closed proposal from the final tally of a vote and the choices of its voters
+func NewClosedProposal(id uint64, proposalType uint16, vote Vote, votes []VoterChoice, outcome string, closingHeight int64) ClosedProposal {
+	if votes == nil {
+		votes = []VoterChoice{}
+	}
+
+	return ClosedProposal{
+		ID:            id,
+		ProposalType:  proposalType,
+		Subject:       vote.GetSubject(),
+		Approvals:     vote.GetApprovals(),
+		Total:         vote.GetTotal(),
+		Votes:         votes,
+		Outcome:       outcome,
+		ClosingHeight: closingHeight,
+	}
+}
+
+// Closed proposal encoding functions
+func MustMarshalClosedProposal(cdc *codec.Codec, p ClosedProposal) []byte {
+	return cdc.MustMarshalBinaryBare(&p)
+}
+func MustUnmarshalClosedProposal(cdc *codec.Codec, value []byte) ClosedProposal {
+	var p ClosedProposal
+	cdc.MustUnmarshalBinaryBare(value, &p)
+	return p
+}
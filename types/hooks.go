@@ -0,0 +1,44 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// combine multiple poa hooks, all hook functions are run in array sequence
+type MultiPoaHooks []PoaHooks
+
+func NewMultiPoaHooks(hooks ...PoaHooks) MultiPoaHooks {
+	return hooks
+}
+
+// nolint
+func (h MultiPoaHooks) AfterApplicationSubmitted(ctx sdk.Context, candidateAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].AfterApplicationSubmitted(ctx, candidateAddr)
+	}
+}
+func (h MultiPoaHooks) AfterValidatorAppended(ctx sdk.Context, valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].AfterValidatorAppended(ctx, valAddr)
+	}
+}
+func (h MultiPoaHooks) AfterValidatorJoined(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].AfterValidatorJoined(ctx, consAddr, valAddr)
+	}
+}
+func (h MultiPoaHooks) AfterKickProposed(ctx sdk.Context, candidateAddr sdk.ValAddress, proposerAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].AfterKickProposed(ctx, candidateAddr, proposerAddr)
+	}
+}
+func (h MultiPoaHooks) BeforeValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].BeforeValidatorRemoved(ctx, consAddr, valAddr)
+	}
+}
+func (h MultiPoaHooks) AfterValidatorRemoved(ctx sdk.Context, consAddr sdk.ConsAddress, valAddr sdk.ValAddress) {
+	for i := range h {
+		h[i].AfterValidatorRemoved(ctx, consAddr, valAddr)
+	}
+}
@@ -16,6 +16,10 @@ const (
 
 	// QuerierRoute to be used for querier msgs
 	QuerierRoute = ModuleName
+
+	// ConsensusVersion is the version of the store layout
+	// It must be incremented with a new migration each time the layout of the store changes
+	ConsensusVersion uint64 = 10
 )
 
 var (
@@ -36,6 +40,54 @@ var (
 
 	// Prefix for the validator kick proposal pool
 	KickProposalPoolKey = []byte{0x26}
+
+	// Key for the consensus version of the store
+	ConsensusVersionKey = []byte{0x27}
+
+	// Prefix for each key to a jailed validator that has been removed from Tendermint validator set
+	ValidatorsOutOfSetKey = []byte{0x28}
+
+	// Key for the consensus address of the previous block proposer
+	PreviousProposerKey = []byte{0x29}
+
+	// Prefix for each key to the rewards of a validator
+	ValidatorRewardsKey = []byte{0x2A}
+
+	// Prefix for each key to the withdraw address of a validator
+	WithdrawAddrKey = []byte{0x2B}
+
+	// Prefix for each key to the height a validator entered its current state
+	ValidatorStateHeightsKey = []byte{0x2C}
+
+	// Prefix for each key to a closed proposal, by id
+	ProposalHistoryKey = []byte{0x2D}
+
+	// Key for the id of the next closed proposal
+	NextClosedProposalIDKey = []byte{0x2E}
+
+	// Prefix for each key to the participation of a validator in the votes
+	ParticipationKey = []byte{0x2F}
+
+	// Prefix for each key to the reason of a kick proposal
+	KickProposalReasonsKey = []byte{0x30}
+
+	// Prefix for each key to the choice of a voter in an application or a kick proposal
+	VotesKey = []byte{0x31}
+
+	// Key for the number of validators
+	ValidatorCountKey = []byte{0x32}
+
+	// Prefix for each key to the number of validators in a state
+	ValidatorStateCountsKey = []byte{0x33}
+
+	// Prefix for each key to the height a validator joined the validator set
+	ValidatorJoinHeightsKey = []byte{0x34}
+
+	// Prefix for each key to a removed validator waiting to leave the last commit, by removal height
+	RemovedValidatorQueueKey = []byte{0x35}
+
+	// Prefix for each key to the height an application or a kick proposal has been opened
+	ProposalOpenHeightsKey = []byte{0x36}
 )
 
 // Get the key for the validator with address
@@ -53,6 +105,61 @@ func GetValidatorStateKey(operatorAddr sdk.ValAddress) []byte {
 	return append(ValidatorStatesKey, operatorAddr.Bytes()...)
 }
 
+// Get the key for the number of validators in a state
+func GetValidatorStateCountKey(state uint16) []byte {
+	return append(ValidatorStateCountsKey, byte(state))
+}
+
+// Get the key for a jailed validator removed from Tendermint validator set
+func GetValidatorOutOfSetKey(operatorAddr sdk.ValAddress) []byte {
+	return append(ValidatorsOutOfSetKey, operatorAddr.Bytes()...)
+}
+
+// Get the key for the rewards of a validator
+func GetValidatorRewardsKey(operatorAddr sdk.ValAddress) []byte {
+	return append(ValidatorRewardsKey, operatorAddr.Bytes()...)
+}
+
+// Get the key for the withdraw address of a validator
+func GetWithdrawAddrKey(operatorAddr sdk.ValAddress) []byte {
+	return append(WithdrawAddrKey, operatorAddr.Bytes()...)
+}
+
+// Get the key for the height a validator entered its current state
+func GetValidatorStateHeightKey(operatorAddr sdk.ValAddress) []byte {
+	return append(ValidatorStateHeightsKey, operatorAddr.Bytes()...)
+}
+
+// Get the key for the height a validator joined the validator set
+func GetValidatorJoinHeightKey(operatorAddr sdk.ValAddress) []byte {
+	return append(ValidatorJoinHeightsKey, operatorAddr.Bytes()...)
+}
+
+// Get the prefix of the keys to the validators removed at a height
+func GetRemovedValidatorQueueHeightKey(height int64) []byte {
+	return append(RemovedValidatorQueueKey, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// Get the key for a validator removed at a height
+func GetRemovedValidatorQueueKey(height int64, consAddr sdk.ConsAddress) []byte {
+	return append(GetRemovedValidatorQueueHeightKey(height), consAddr.Bytes()...)
+}
+
+// Get the key for the participation of a validator in the votes
+func GetParticipationKey(operatorAddr sdk.ValAddress) []byte {
+	return append(ParticipationKey, operatorAddr.Bytes()...)
+}
+
+// Get the key for the reason of the kick proposal against a validator
+func GetKickProposalReasonKey(operatorAddr sdk.ValAddress) []byte {
+	return append(KickProposalReasonsKey, operatorAddr.Bytes()...)
+}
+
+// Get the key for the closed proposal with id
+func GetClosedProposalKey(id uint64) []byte {
+	return append(ProposalHistoryKey, sdk.Uint64ToBigEndian(id)...)
+}
+
 // Get the key for a validator canditate application with address
 func GetApplicationKey(operatorAddr sdk.ValAddress) []byte {
 	return append(ApplicationPoolKey, operatorAddr.Bytes()...)
@@ -67,3 +174,30 @@ func GetApplicationByConsAddrKey(addr sdk.ConsAddress) []byte {
 func GetKickProposalKey(operatorAddr sdk.ValAddress) []byte {
 	return append(KickProposalPoolKey, operatorAddr.Bytes()...)
 }
+
+// Get the key of the application or the kick proposal against a candidate
+func GetProposalKey(voteType uint16, candidateAddr sdk.ValAddress) []byte {
+	switch voteType {
+	case VoteTypeApplication:
+		return GetApplicationKey(candidateAddr)
+	case VoteTypeKickProposal:
+		return GetKickProposalKey(candidateAddr)
+	default:
+		panic("Incorrect vote type")
+	}
+}
+
+// Get the prefix of the keys to the choices of the voters in a proposal
+func GetVotesKey(voteType uint16, candidateAddr sdk.ValAddress) []byte {
+	return append(VotesKey, GetProposalKey(voteType, candidateAddr)...)
+}
+
+// Get the key for the choice of a voter in a proposal
+func GetVoteKey(voteType uint16, candidateAddr sdk.ValAddress, voterAddr sdk.ValAddress) []byte {
+	return append(GetVotesKey(voteType, candidateAddr), voterAddr.Bytes()...)
+}
+
+// Get the key for the height the application or the kick proposal against a candidate has been opened
+func GetProposalOpenHeightKey(voteType uint16, candidateAddr sdk.ValAddress) []byte {
+	return append(ProposalOpenHeightsKey, GetProposalKey(voteType, candidateAddr)...)
+}
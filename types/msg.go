@@ -8,8 +8,11 @@ import (
 // verify interface at compile time
 var _ sdk.Msg = &MsgSubmitApplication{}
 var _ sdk.Msg = &MsgVote{}
+var _ sdk.Msg = &MsgVoteBatch{}
 var _ sdk.Msg = &MsgProposeKick{}
 var _ sdk.Msg = &MsgLeaveValidatorSet{}
+var _ sdk.Msg = &MsgSetWithdrawAddress{}
+var _ sdk.Msg = &MsgWithdrawRewards{}
 
 /**
  * MsgSubmitApplication
@@ -108,6 +111,24 @@ const (
 	VoteTypeKickProposal uint16 = iota
 )
 
+// Reasons of a kick proposal
+const (
+	KickReasonProposed    uint16 = iota // The kick proposal has been proposed by a validator
+	KickReasonMissedVotes uint16 = iota // The validator missed more consecutive votes than the max missed votes param
+)
+
+// Get the name of a kick proposal reason
+func KickReasonToString(reason uint16) string {
+	switch reason {
+	case KickReasonProposed:
+		return "proposed"
+	case KickReasonMissedVotes:
+		return "missed_votes"
+	default:
+		return "unknown"
+	}
+}
+
 func (msg MsgVote) Route() string { return RouterKey }
 func (msg MsgVote) Type() string  { return VoteConst }
 func (msg MsgVote) GetSigners() []sdk.AccAddress {
@@ -132,6 +153,85 @@ func (msg MsgVote) ValidateBasic() error {
 	return nil
 }
 
+/**
+ * MsgVoteBatch
+ */
+
+// A vote of a batch on an application or a kick proposal
+type BatchVote struct {
+	VoteType      uint16         `json:"votetype"`
+	CandidateAddr sdk.ValAddress `json:"candidate"`
+	Approve       bool           `json:"approve"`
+}
+
+func NewBatchVote(voteType uint16, candidate sdk.ValAddress, approve bool) BatchVote {
+	return BatchVote{
+		VoteType:      voteType,
+		CandidateAddr: candidate,
+		Approve:       approve,
+	}
+}
+
+// Several votes performed by a validator in a single message
+// The votes are processed in order and the message fails if one of the votes fails
+type MsgVoteBatch struct {
+	VoterAddr sdk.ValAddress `json:"voter"`
+	Votes     []BatchVote    `json:"votes"`
+}
+
+func NewMsgVoteBatch(voter sdk.ValAddress, votes []BatchVote) MsgVoteBatch {
+	return MsgVoteBatch{
+		VoterAddr: voter,
+		Votes:     votes,
+	}
+}
+
+const VoteBatchConst = "VoteBatch"
+
+func (msg MsgVoteBatch) Route() string { return RouterKey }
+func (msg MsgVoteBatch) Type() string  { return VoteBatchConst }
+func (msg MsgVoteBatch) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.VoterAddr)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgVoteBatch) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// Get the vote messages of the batch
+func (msg MsgVoteBatch) MsgVotes() []MsgVote {
+	msgVotes := make([]MsgVote, len(msg.Votes))
+	for i, vote := range msg.Votes {
+		msgVotes[i] = NewMsgVote(vote.VoteType, msg.VoterAddr, vote.CandidateAddr, vote.Approve)
+	}
+	return msgVotes
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgVoteBatch) ValidateBasic() error {
+	if len(msg.Votes) == 0 {
+		return sdkerrors.Wrap(ErrInvalidVoteMsg, "no vote in the batch")
+	}
+
+	// A proposal cannot be voted twice in the batch
+	voted := make(map[string]bool)
+	for i, msgVote := range msg.MsgVotes() {
+		if err := msgVote.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "vote %d", i)
+		}
+
+		key := string(append([]byte{byte(msgVote.VoteType)}, msgVote.CandidateAddr...))
+		if voted[key] {
+			return sdkerrors.Wrapf(ErrInvalidVoteMsg, "vote %d: the proposal of %s is voted twice", i, msgVote.CandidateAddr)
+		}
+		voted[key] = true
+	}
+
+	return nil
+}
+
 /**
  * MsgLeaveValidatorSet
  */
@@ -168,3 +268,79 @@ func (msg MsgLeaveValidatorSet) ValidateBasic() error {
 
 	return nil
 }
+
+/**
+ * MsgSetWithdrawAddress
+ */
+
+type MsgSetWithdrawAddress struct {
+	ValidatorAddr sdk.ValAddress `json:"validator"`
+	WithdrawAddr  sdk.AccAddress `json:"withdraw_address"`
+}
+
+func NewMsgSetWithdrawAddress(validatorAddr sdk.ValAddress, withdrawAddr sdk.AccAddress) MsgSetWithdrawAddress {
+	return MsgSetWithdrawAddress{
+		ValidatorAddr: validatorAddr,
+		WithdrawAddr:  withdrawAddr,
+	}
+}
+
+const SetWithdrawAddressConst = "SetWithdrawAddress"
+
+func (msg MsgSetWithdrawAddress) Route() string { return RouterKey }
+func (msg MsgSetWithdrawAddress) Type() string  { return SetWithdrawAddressConst }
+func (msg MsgSetWithdrawAddress) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgSetWithdrawAddress) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgSetWithdrawAddress) ValidateBasic() error {
+	if msg.ValidatorAddr.Empty() || msg.WithdrawAddr.Empty() {
+		return sdkerrors.Wrap(ErrInvalidWithdrawMsg, "missing address")
+	}
+
+	return nil
+}
+
+/**
+ * MsgWithdrawRewards
+ */
+
+type MsgWithdrawRewards struct {
+	ValidatorAddr sdk.ValAddress `json:"validator"`
+}
+
+func NewMsgWithdrawRewards(validatorAddr sdk.ValAddress) MsgWithdrawRewards {
+	return MsgWithdrawRewards{
+		ValidatorAddr: validatorAddr,
+	}
+}
+
+const WithdrawRewardsConst = "WithdrawRewards"
+
+func (msg MsgWithdrawRewards) Route() string { return RouterKey }
+func (msg MsgWithdrawRewards) Type() string  { return WithdrawRewardsConst }
+func (msg MsgWithdrawRewards) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgWithdrawRewards) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgWithdrawRewards) ValidateBasic() error {
+	if msg.ValidatorAddr.Empty() {
+		return sdkerrors.Wrap(ErrInvalidWithdrawMsg, "missing address")
+	}
+
+	return nil
+}
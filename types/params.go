@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
@@ -12,12 +13,25 @@ const (
 	DefaultMaxValidators uint16 = 15
 	// Default quorum percentage
 	DefaultQuorum uint16 = 66
+	// Default max number of consecutive missed votes, validators are never kicked automatically
+	DefaultMaxMissedVotes uint64 = 0
+)
+
+// Default distribution parameters
+var (
+	// Default block reward, no coins are minted
+	DefaultBlockReward sdk.Coins
+	// Default proposer bonus, the proposer receives the same rewards as the other validators
+	DefaultProposerBonus = sdk.ZeroDec()
 )
 
 // Parameter store keys
 var (
-	KeyMaxValidators = []byte("MaxValidators")
-	KeyQuorum        = []byte("Quorum")
+	KeyMaxValidators  = []byte("MaxValidators")
+	KeyQuorum         = []byte("Quorum")
+	KeyBlockReward    = []byte("BlockReward")
+	KeyProposerBonus  = []byte("ProposerBonus")
+	KeyMaxMissedVotes = []byte("MaxMissedVotes")
 )
 
 // ParamKeyTable for poa module
@@ -27,21 +41,43 @@ func ParamKeyTable() params.KeyTable {
 
 // Params - used for initializing default parameter for poa at genesis
 type Params struct {
-	MaxValidators uint16 `json:"max_validators"`
-	Quorum        uint16 `json:"quorum"`
+	MaxValidators uint16    `json:"max_validators"`
+	Quorum        uint16    `json:"quorum"`
+	BlockReward   sdk.Coins `json:"block_reward"`
+	ProposerBonus sdk.Dec   `json:"proposer_bonus"`
+
+	// A kick proposal is automatically opened against a validator missing more consecutive votes, 0 disables it
+	MaxMissedVotes uint64 `json:"max_missed_votes"`
 }
 
-// NewParams creates a new Params object
+// NewParams creates a new Params object with the default distribution and missed votes parameters
 func NewParams(maxValidators uint16, quorum uint16) Params {
 	return Params{
-		MaxValidators: maxValidators,
-		Quorum:        quorum,
+		MaxValidators:  maxValidators,
+		Quorum:         quorum,
+		BlockReward:    DefaultBlockReward,
+		ProposerBonus:  DefaultProposerBonus,
+		MaxMissedVotes: DefaultMaxMissedVotes,
 	}
 }
 
+// Set the distribution parameters
+func (p Params) WithRewards(blockReward sdk.Coins, proposerBonus sdk.Dec) Params {
+	p.BlockReward = blockReward
+	p.ProposerBonus = proposerBonus
+	return p
+}
+
+// Set the max number of consecutive missed votes
+func (p Params) WithMaxMissedVotes(maxMissedVotes uint64) Params {
+	p.MaxMissedVotes = maxMissedVotes
+	return p
+}
+
 // String implements the stringer interface for Params
 func (p Params) String() string {
-	return fmt.Sprintf("Max validators: %d, quorum: %d percents", p.MaxValidators, p.Quorum)
+	return fmt.Sprintf("Max validators: %d, quorum: %d percents, block reward: %s, proposer bonus: %s, max missed votes: %d",
+		p.MaxValidators, p.Quorum, p.BlockReward, p.ProposerBonus, p.MaxMissedVotes)
 }
 
 // ParamSetPairs - Implements params.ParamSet
@@ -49,6 +85,9 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		params.NewParamSetPair(KeyMaxValidators, &p.MaxValidators, validateMaxValidators),
 		params.NewParamSetPair(KeyQuorum, &p.Quorum, validateQuorum),
+		params.NewParamSetPair(KeyBlockReward, &p.BlockReward, validateBlockReward),
+		params.NewParamSetPair(KeyProposerBonus, &p.ProposerBonus, validateProposerBonus),
+		params.NewParamSetPair(KeyMaxMissedVotes, &p.MaxMissedVotes, validateMaxMissedVotes),
 	}
 }
 
@@ -65,6 +104,15 @@ func (p Params) Validate() error {
 	if err := validateQuorum(p.Quorum); err != nil {
 		return err
 	}
+	if err := validateBlockReward(p.BlockReward); err != nil {
+		return err
+	}
+	if err := validateProposerBonus(p.ProposerBonus); err != nil {
+		return err
+	}
+	if err := validateMaxMissedVotes(p.MaxMissedVotes); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -95,3 +143,41 @@ func validateQuorum(i interface{}) error {
 
 	return nil
 }
+
+// Block reward must be valid coins
+func validateBlockReward(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsValid() {
+		return fmt.Errorf("invalid block reward: %s", v)
+	}
+
+	return nil
+}
+
+// Proposer bonus must be a fraction of the rewards
+func validateProposerBonus(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("proposer bonus must be between 0 and 1: %s", v)
+	}
+
+	return nil
+}
+
+// Max missed votes can be any number
+func validateMaxMissedVotes(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
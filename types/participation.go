@@ -0,0 +1,26 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// Participation of a validator in the votes on the applications and the kick proposals
+// MissedVotes counts the expired proposals without the vote of the validator while it was eligible
+// ConsecutiveMissedVotes counts the missed votes since the last vote of the validator
+type Participation struct {
+	ApplicationVotes       uint64 `json:"application_votes" yaml:"application_votes"`
+	KickProposalVotes      uint64 `json:"kick_proposal_votes" yaml:"kick_proposal_votes"`
+	MissedVotes            uint64 `json:"missed_votes" yaml:"missed_votes"`
+	ConsecutiveMissedVotes uint64 `json:"consecutive_missed_votes" yaml:"consecutive_missed_votes"`
+	LastVoteHeight         int64  `json:"last_vote_height" yaml:"last_vote_height"`
+}
+
+// Participation encoding functions
+func MustMarshalParticipation(cdc *codec.Codec, p Participation) []byte {
+	return cdc.MustMarshalBinaryBare(&p)
+}
+func MustUnmarshalParticipation(cdc *codec.Codec, value []byte) Participation {
+	var p Participation
+	cdc.MustUnmarshalBinaryBare(value, &p)
+	return p
+}
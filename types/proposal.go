@@ -0,0 +1,107 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	ProposalTypeAddValidator    = "AddValidator"
+	ProposalTypeRemoveValidator = "RemoveValidator"
+)
+
+// verify interface at compile time
+var _ govtypes.Content = AddValidatorProposal{}
+var _ govtypes.Content = RemoveValidatorProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeAddValidator)
+	govtypes.RegisterProposalTypeCodec(AddValidatorProposal{}, "poa/AddValidatorProposal")
+	govtypes.RegisterProposalType(ProposalTypeRemoveValidator)
+	govtypes.RegisterProposalTypeCodec(RemoveValidatorProposal{}, "poa/RemoveValidatorProposal")
+}
+
+/**
+ * AddValidatorProposal
+ */
+
+// AddValidatorProposal appends a validator in the validator set through governance
+type AddValidatorProposal struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Validator   Validator `json:"validator"`
+}
+
+func NewAddValidatorProposal(title, description string, validator Validator) AddValidatorProposal {
+	return AddValidatorProposal{
+		Title:       title,
+		Description: description,
+		Validator:   validator,
+	}
+}
+
+// nolint
+func (p AddValidatorProposal) GetTitle() string       { return p.Title }
+func (p AddValidatorProposal) GetDescription() string { return p.Description }
+func (p AddValidatorProposal) ProposalRoute() string  { return RouterKey }
+func (p AddValidatorProposal) ProposalType() string   { return ProposalTypeAddValidator }
+
+// ValidateBasic validity check of the proposal
+func (p AddValidatorProposal) ValidateBasic() error {
+	if err := p.Validator.CheckValid(); err != nil {
+		return err
+	}
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p AddValidatorProposal) String() string {
+	return fmt.Sprintf(`Add Validator Proposal:
+  Title:       %s
+  Description: %s
+  Validator:   %s
+`, p.Title, p.Description, p.Validator.GetOperator())
+}
+
+/**
+ * RemoveValidatorProposal
+ */
+
+// RemoveValidatorProposal removes a validator from the validator set through governance
+type RemoveValidatorProposal struct {
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	ValidatorAddr sdk.ValAddress `json:"validator"`
+}
+
+func NewRemoveValidatorProposal(title, description string, validatorAddr sdk.ValAddress) RemoveValidatorProposal {
+	return RemoveValidatorProposal{
+		Title:         title,
+		Description:   description,
+		ValidatorAddr: validatorAddr,
+	}
+}
+
+// nolint
+func (p RemoveValidatorProposal) GetTitle() string       { return p.Title }
+func (p RemoveValidatorProposal) GetDescription() string { return p.Description }
+func (p RemoveValidatorProposal) ProposalRoute() string  { return RouterKey }
+func (p RemoveValidatorProposal) ProposalType() string   { return ProposalTypeRemoveValidator }
+
+// ValidateBasic validity check of the proposal
+func (p RemoveValidatorProposal) ValidateBasic() error {
+	if p.ValidatorAddr.Empty() {
+		return sdkerrors.Wrap(ErrInvalidValidator, "missing address")
+	}
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p RemoveValidatorProposal) String() string {
+	return fmt.Sprintf(`Remove Validator Proposal:
+  Title:       %s
+  Description: %s
+  Validator:   %s
+`, p.Title, p.Description, p.ValidatorAddr)
+}
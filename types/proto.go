@@ -0,0 +1,153 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ltacker/poa/types/pb"
+)
+
+// Conversion between the amino types and the protobuf types of proto/poa/v1
+// The addresses are their raw bytes, the sdk.Dec and sdk.Int amounts are decimal strings
+
+// Convert a description to its protobuf type
+func DescriptionToProto(d Description) *pb.Description {
+	return &pb.Description{
+		Moniker:         d.Moniker,
+		Identity:        d.Identity,
+		Website:         d.Website,
+		SecurityContact: d.SecurityContact,
+		Details:         d.Details,
+	}
+}
+
+// Convert a description from its protobuf type
+func DescriptionFromProto(d *pb.Description) Description {
+	if d == nil {
+		return Description{}
+	}
+
+	return NewDescription(d.Moniker, d.Identity, d.Website, d.SecurityContact, d.Details)
+}
+
+// Convert a validator to its protobuf type
+func ValidatorToProto(v Validator) *pb.Validator {
+	return &pb.Validator{
+		OperatorAddress: v.OperatorAddress,
+		ConsensusPubkey: v.ConsensusPubkey,
+		Description:     DescriptionToProto(v.Description),
+	}
+}
+
+// Convert a validator from its protobuf type
+func ValidatorFromProto(v *pb.Validator) Validator {
+	if v == nil {
+		return Validator{}
+	}
+
+	return Validator{
+		OperatorAddress: v.OperatorAddress,
+		ConsensusPubkey: v.ConsensusPubkey,
+		Description:     DescriptionFromProto(v.Description),
+	}
+}
+
+// Convert a validator with its state to its protobuf type
+func ValidatorWithStateToProto(v ValidatorWithState) *pb.ValidatorWithState {
+	return &pb.ValidatorWithState{
+		Validator:   ValidatorToProto(v.Validator),
+		State:       v.State,
+		StateHeight: v.StateHeight,
+	}
+}
+
+// Convert a vote to its protobuf type
+func VoteToProto(v Vote) *pb.Vote {
+	return &pb.Vote{
+		Subject:   ValidatorToProto(v.Subject),
+		Approvals: v.Approvals,
+		Total:     v.Total,
+	}
+}
+
+// Convert a voter choice to its protobuf type
+func VoterChoiceToProto(c VoterChoice) *pb.VoterChoice {
+	return &pb.VoterChoice{
+		Voter:   c.Voter,
+		Approve: c.Approve,
+		Unknown: c.Unknown,
+	}
+}
+
+// Convert coins to their protobuf type
+func CoinsToProto(coins sdk.Coins) []*pb.Coin {
+	protoCoins := make([]*pb.Coin, len(coins))
+	for i, coin := range coins {
+		protoCoins[i] = &pb.Coin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.String(),
+		}
+	}
+
+	return protoCoins
+}
+
+// Convert params to their protobuf type
+func ParamsToProto(p Params) *pb.Params {
+	return &pb.Params{
+		MaxValidators:  uint32(p.MaxValidators),
+		Quorum:         uint32(p.Quorum),
+		BlockReward:    CoinsToProto(p.BlockReward),
+		ProposerBonus:  p.ProposerBonus.String(),
+		MaxMissedVotes: p.MaxMissedVotes,
+	}
+}
+
+// Convert a message to submit an application from its protobuf type
+func MsgSubmitApplicationFromProto(msg *pb.MsgSubmitApplication) MsgSubmitApplication {
+	return NewMsgSubmitApplication(ValidatorFromProto(msg.Candidate))
+}
+
+// Convert a message to propose a kick from its protobuf type
+func MsgProposeKickFromProto(msg *pb.MsgProposeKick) MsgProposeKick {
+	return NewMsgProposeKick(msg.CandidateAddr, msg.ProposerAddr)
+}
+
+// Convert a vote type from its protobuf type
+// A vote type that doesn't fit in an uint16 is rejected by ValidateBasic
+func voteTypeFromProto(voteType uint32) uint16 {
+	if voteType > uint32(^uint16(0)) {
+		return ^uint16(0)
+	}
+
+	return uint16(voteType)
+}
+
+// Convert a message to vote from its protobuf type
+func MsgVoteFromProto(msg *pb.MsgVote) MsgVote {
+	return NewMsgVote(voteTypeFromProto(msg.VoteType), msg.VoterAddr, msg.CandidateAddr, msg.Approve)
+}
+
+// Convert a message to vote in batch from its protobuf type
+func MsgVoteBatchFromProto(msg *pb.MsgVoteBatch) MsgVoteBatch {
+	votes := make([]BatchVote, len(msg.Votes))
+	for i, vote := range msg.Votes {
+		votes[i] = NewBatchVote(voteTypeFromProto(vote.VoteType), vote.CandidateAddr, vote.Approve)
+	}
+
+	return NewMsgVoteBatch(msg.VoterAddr, votes)
+}
+
+// Convert a message to leave the validator set from its protobuf type
+func MsgLeaveValidatorSetFromProto(msg *pb.MsgLeaveValidatorSet) MsgLeaveValidatorSet {
+	return NewMsgLeaveValidatorSet(msg.ValidatorAddr)
+}
+
+// Convert a message to set the withdraw address from its protobuf type
+func MsgSetWithdrawAddressFromProto(msg *pb.MsgSetWithdrawAddress) MsgSetWithdrawAddress {
+	return NewMsgSetWithdrawAddress(msg.ValidatorAddr, msg.WithdrawAddr)
+}
+
+// Convert a message to withdraw the rewards from its protobuf type
+func MsgWithdrawRewardsFromProto(msg *pb.MsgWithdrawRewards) MsgWithdrawRewards {
+	return NewMsgWithdrawRewards(msg.ValidatorAddr)
+}
@@ -6,15 +6,28 @@ import (
 
 // Query endpoints supported by the poa querier
 const (
-	QueryValidators    = "validators"
-	QueryValidator     = "validator"
-	QueryParams        = "params"
-	QueryApplications  = "applications"
-	QueryKickProposals = "kick-proposals"
+	QueryValidators            = "validators"
+	QueryValidator             = "validator"
+	QueryValidatorByConsAddr   = "validator-by-cons-addr"
+	QueryApplication           = "application"
+	QueryApplicationByConsAddr = "application-by-cons-addr"
+	QueryParams                = "params"
+	QueryApplications          = "applications"
+	QueryKickProposals         = "kick-proposals"
+	QueryKickProposal          = "kick-proposal"
+	QueryRewards               = "rewards"
+	QueryWithdrawAddress       = "withdraw-address"
+	QueryProposalHistory       = "proposal-history"
+	QueryParticipation         = "participation"
 )
 
 // Defines the params for the following queries:
 // - 'custom/poa/validator'
+// - 'custom/poa/application'
+// - 'custom/poa/kick-proposal'
+// - 'custom/poa/rewards'
+// - 'custom/poa/withdraw-address'
+// - 'custom/poa/participation'
 type QueryValidatorParams struct {
 	ValidatorAddr sdk.ValAddress
 }
@@ -24,3 +37,63 @@ func NewQueryValidatorParams(validatorAddr sdk.ValAddress) QueryValidatorParams
 		ValidatorAddr: validatorAddr,
 	}
 }
+
+// Defines the params for the following queries:
+// - 'custom/poa/validator-by-cons-addr'
+// - 'custom/poa/application-by-cons-addr'
+type QueryConsAddrParams struct {
+	ConsAddr sdk.ConsAddress
+}
+
+func NewQueryConsAddrParams(consAddr sdk.ConsAddress) QueryConsAddrParams {
+	return QueryConsAddrParams{
+		ConsAddr: consAddr,
+	}
+}
+
+// Sort orders of the list queries
+const (
+	SortByOperator  = "operator"  // Order of the operator addresses, the default order
+	SortByMoniker   = "moniker"   // Alphabetical order of the monikers
+	SortByApprovals = "approvals" // Most approved first, only for the applications and the kick proposals
+)
+
+// Defines the params for the following queries:
+// - 'custom/poa/validators'
+// - 'custom/poa/applications'
+// - 'custom/poa/kick-proposals'
+// A limit of 0 returns all the results, the status filter only applies to the validators
+type QueryListParams struct {
+	Page   int
+	Limit  int
+	Status string
+	SortBy string
+}
+
+func NewQueryListParams(page, limit int, status, sortBy string) QueryListParams {
+	return QueryListParams{
+		Page:   page,
+		Limit:  limit,
+		Status: status,
+		SortBy: sortBy,
+	}
+}
+
+// Defines the params for the 'custom/poa/proposal-history' query
+// The closed proposals are filtered by candidate and outcome when they are set
+// A limit of 0 returns all the results
+type QueryProposalHistoryParams struct {
+	Page      int
+	Limit     int
+	Candidate sdk.ValAddress
+	Outcome   string
+}
+
+func NewQueryProposalHistoryParams(page, limit int, candidate sdk.ValAddress, outcome string) QueryProposalHistoryParams {
+	return QueryProposalHistoryParams{
+		Page:      page,
+		Limit:     limit,
+		Candidate: candidate,
+		Outcome:   outcome,
+	}
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingexported "github.com/cosmos/cosmos-sdk/x/staking/exported"
+)
+
+// verify interface at compile time
+var _ stakingexported.ValidatorI = StakingValidator{}
+var _ stakingexported.DelegationI = SelfDelegation{}
+
+// Every validator has the same weight, its tokens are the tokens for a consensus power of 1
+var validatorTokens = sdk.TokensFromConsensusPower(1)
+
+// StakingValidator exposes a validator to the modules relying on the staking validator interface (slashing, evidence...)
+type StakingValidator struct {
+	Validator
+	Status sdk.BondStatus `json:"status"`
+	Jailed bool           `json:"jailed"`
+}
+
+// Create a staking validator from a validator and its state
+// outOfSet is true if the validator has been removed from Tendermint validator set while jailed
+func NewStakingValidator(validator Validator, state uint16, outOfSet bool) StakingValidator {
+	// The validator is bonded as long as it is present in Tendermint validator set
+	status := sdk.Unbonded
+	switch state {
+	case ValidatorStateJoined, ValidatorStateJailing:
+		status = sdk.Bonded
+	case ValidatorStateLeaving:
+		if !outOfSet {
+			status = sdk.Bonded
+		}
+	}
+
+	return StakingValidator{
+		Validator: validator,
+		Status:    status,
+		Jailed:    state == ValidatorStateJailing || state == ValidatorStateJailed,
+	}
+}
+
+// Implements stakingexported.ValidatorI
+func (v StakingValidator) IsJailed() bool            { return v.Jailed }
+func (v StakingValidator) GetMoniker() string        { return v.Description.Moniker }
+func (v StakingValidator) GetStatus() sdk.BondStatus { return v.Status }
+func (v StakingValidator) IsBonded() bool            { return v.Status == sdk.Bonded }
+func (v StakingValidator) IsUnbonded() bool          { return v.Status == sdk.Unbonded }
+func (v StakingValidator) IsUnbonding() bool         { return v.Status == sdk.Unbonding }
+func (v StakingValidator) GetTokens() sdk.Int        { return validatorTokens }
+func (v StakingValidator) GetBondedTokens() sdk.Int {
+	if v.IsBonded() {
+		return validatorTokens
+	}
+	return sdk.ZeroInt()
+}
+func (v StakingValidator) GetConsensusPower() int64 {
+	if v.IsBonded() {
+		return 1
+	}
+	return 0
+}
+func (v StakingValidator) GetCommission() sdk.Dec        { return sdk.ZeroDec() }
+func (v StakingValidator) GetMinSelfDelegation() sdk.Int { return sdk.ZeroInt() }
+func (v StakingValidator) GetDelegatorShares() sdk.Dec   { return validatorTokens.ToDec() }
+
+// Validators don't have delegators, a share is worth a token
+func (v StakingValidator) TokensFromShares(shares sdk.Dec) sdk.Dec          { return shares }
+func (v StakingValidator) TokensFromSharesTruncated(shares sdk.Dec) sdk.Dec { return shares }
+func (v StakingValidator) TokensFromSharesRoundUp(shares sdk.Dec) sdk.Dec   { return shares }
+func (v StakingValidator) SharesFromTokens(amt sdk.Int) (sdk.Dec, error)    { return amt.ToDec(), nil }
+func (v StakingValidator) SharesFromTokensTruncated(amt sdk.Int) (sdk.Dec, error) {
+	return amt.ToDec(), nil
+}
+
+// SelfDelegation is the only delegation of a validator, from its operator
+type SelfDelegation struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_address"`
+}
+
+// Implements stakingexported.DelegationI
+func (d SelfDelegation) GetDelegatorAddr() sdk.AccAddress { return sdk.AccAddress(d.ValidatorAddr) }
+func (d SelfDelegation) GetValidatorAddr() sdk.ValAddress { return d.ValidatorAddr }
+func (d SelfDelegation) GetShares() sdk.Dec               { return validatorTokens.ToDec() }
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -116,4 +119,68 @@ const (
 	ValidatorStateJoining uint16 = iota // The validator is joining the validator set, it is not yet present in Tendermint validator set
 	ValidatorStateJoined  uint16 = iota // The validator is already present in Tendermind validator set
 	ValidatorStateLeaving uint16 = iota // The validator is leaving the validator set, it will leave Tendermint validator set at the end of the block
+	ValidatorStateJailing uint16 = iota // The validator has been jailed, it will leave Tendermint validator set at the end of the block but remains in the validator set
+	ValidatorStateJailed  uint16 = iota // The validator is jailed, it is not present in Tendermint validator set until it is unjailed
 )
+
+// Human-readable names of the validator states
+var validatorStateNames = map[uint16]string{
+	ValidatorStateJoining: "joining",
+	ValidatorStateJoined:  "joined",
+	ValidatorStateLeaving: "leaving",
+	ValidatorStateJailing: "jailing",
+	ValidatorStateJailed:  "jailed",
+}
+
+// Get the name of a validator state
+func ValidatorStateToString(state uint16) string {
+	name, ok := validatorStateNames[state]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+// Get a validator state from its name
+func ValidatorStateFromString(name string) (uint16, error) {
+	for state, stateName := range validatorStateNames {
+		if stateName == strings.ToLower(name) {
+			return state, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown validator state: %s", name)
+}
+
+// Validator returned by the queries with its state
+// StateHeight is the height the validator entered the state, 0 if it has not changed state since genesis
+type ValidatorWithState struct {
+	Validator   Validator `json:"validator" yaml:"validator"`
+	State       string    `json:"state" yaml:"state"`
+	StateHeight int64     `json:"state_height" yaml:"state_height"`
+}
+
+func NewValidatorWithState(validator Validator, state uint16, stateHeight int64) ValidatorWithState {
+	return ValidatorWithState{
+		Validator:   validator,
+		State:       ValidatorStateToString(state),
+		StateHeight: stateHeight,
+	}
+}
+
+// Check if a bech32 string is a consensus address (valcons...) or a consensus public key (valconspub...)
+func IsConsensusBech32(bech32Str string) bool {
+	return strings.HasPrefix(bech32Str, sdk.GetConfig().GetBech32ConsensusAddrPrefix())
+}
+
+// Get a consensus address from a bech32 consensus address or consensus public key
+func ConsAddressFromBech32(bech32Str string) (sdk.ConsAddress, error) {
+	if strings.HasPrefix(bech32Str, sdk.GetConfig().GetBech32ConsensusPubPrefix()) {
+		pk, err := sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeConsPub, bech32Str)
+		if err != nil {
+			return nil, err
+		}
+		return sdk.ConsAddress(pk.Address()), nil
+	}
+
+	return sdk.ConsAddressFromBech32(bech32Str)
+}
@@ -0,0 +1,46 @@
+package types_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ltacker/poa"
+	"github.com/ltacker/poa/types"
+)
+
+func TestConsAddressFromBech32(t *testing.T) {
+	validator, consPubKey := poa.MockValidator()
+
+	// A consensus public key resolves to the consensus address of the validator
+	if !types.IsConsensusBech32(consPubKey) {
+		t.Errorf("IsConsensusBech32 should be true for a consensus public key")
+	}
+	consAddr, err := types.ConsAddressFromBech32(consPubKey)
+	if err != nil {
+		t.Fatalf("ConsAddressFromBech32 should resolve a consensus public key, got error %v", err)
+	}
+	if !consAddr.Equals(validator.GetConsAddr()) {
+		t.Errorf("ConsAddressFromBech32 should return %v, got %v", validator.GetConsAddr(), consAddr)
+	}
+
+	// A consensus address is parsed directly
+	if !types.IsConsensusBech32(validator.GetConsAddr().String()) {
+		t.Errorf("IsConsensusBech32 should be true for a consensus address")
+	}
+	consAddr, err = types.ConsAddressFromBech32(validator.GetConsAddr().String())
+	if err != nil {
+		t.Fatalf("ConsAddressFromBech32 should parse a consensus address, got error %v", err)
+	}
+	if !consAddr.Equals(validator.GetConsAddr()) {
+		t.Errorf("ConsAddressFromBech32 should return %v, got %v", validator.GetConsAddr(), consAddr)
+	}
+
+	// An operator address is not a consensus address
+	if types.IsConsensusBech32(validator.GetOperator().String()) {
+		t.Errorf("IsConsensusBech32 should be false for an operator address")
+	}
+	if _, err := types.ConsAddressFromBech32(sdk.AccAddress(validator.GetOperator()).String()); err == nil {
+		t.Errorf("ConsAddressFromBech32 should fail for an account address")
+	}
+}
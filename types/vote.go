@@ -10,11 +10,11 @@ import (
 // Structure to track the vote for:
 // - An application to become validator
 // - A proposal to kick a validator
+// Only the tally counters are kept in the vote, the choice of each voter is stored under its own key
 type Vote struct {
-	Subject   Validator        `json:"subject"`
-	Approvals uint64           `json:"approvals"`
-	Total     uint64           `json:"totals"`
-	Voters    []sdk.ValAddress `json:"voter"`
+	Subject   Validator `json:"subject"`
+	Approvals uint64    `json:"approvals"`
+	Total     uint64    `json:"totals"`
 }
 
 func NewVote(subject Validator) Vote {
@@ -22,7 +22,6 @@ func NewVote(subject Validator) Vote {
 		Subject:   subject,
 		Approvals: 0,
 		Total:     0,
-		Voters:    []sdk.ValAddress{},
 	}
 }
 
@@ -41,26 +40,13 @@ func (v Vote) GetTotal() uint64 {
 	return v.Total
 }
 
-// Add a vote
-func (v *Vote) AddVote(voter sdk.ValAddress, approve bool) (alreadyVoted bool) {
-	// Verify if the voter already voted
-	for _, currentVoter := range v.Voters {
-		if voter.Equals(currentVoter) {
-			// The voter already voted
-			return true
-		}
-	}
-
-	// Append the voter in the voters list
-	v.Voters = append(v.Voters, voter)
-
-	// Update vote status
+// Count a new vote in the tally counters
+// The keeper checks the voter has not already voted before counting the vote
+func (v *Vote) CountVote(approve bool) {
 	v.Total += 1
 	if approve {
 		v.Approvals += 1
 	}
-
-	return false
 }
 
 // Check if the quorum has been reached
@@ -78,7 +64,7 @@ func (v Vote) CheckQuorum(voterPoolSize uint64, quorum uint64) (reached bool, ap
 	}
 
 	// Get the necessary number of approval to approve the vote
-	necessaryApproval := uint64(math.Ceil(float64(voterPoolSize*quorum) / 100.0))
+	necessaryApproval := NecessaryApprovals(voterPoolSize, quorum)
 
 	// Check if the vote is approved
 	if v.Approvals >= necessaryApproval {
@@ -98,6 +84,58 @@ func (v Vote) CheckQuorum(voterPoolSize uint64, quorum uint64) (reached bool, ap
 	}
 }
 
+// Get the necessary number of approvals to approve a vote
+// voterPoolSize is the total number of possible voters in the vote
+// Quorum is the percentage of voters to reach to approve the vote
+func NecessaryApprovals(voterPoolSize uint64, quorum uint64) uint64 {
+	return uint64(math.Ceil(float64(voterPoolSize*quorum) / 100.0))
+}
+
+// Detailed tally of a vote
+// Votes are the choices of the voters who have voted
+// RemainingVoters are the voters of the pool who have not voted yet
+// CanPass is true if the vote can still be approved by the remaining voters
+type Tally struct {
+	Vote              Vote             `json:"vote"`
+	Votes             []VoterChoice    `json:"votes"`
+	VoterPoolSize     uint64           `json:"voter_pool_size"`
+	RequiredApprovals uint64           `json:"required_approvals"`
+	RemainingVoters   []sdk.ValAddress `json:"remaining_voters"`
+	CanPass           bool             `json:"can_pass"`
+}
+
+// Compute the tally of the vote
+// voterPool contains all the possible voters in the vote
+// votes contains the choices of the voters who have voted
+// Quorum is the percentage of voters to reach to approve the vote
+func (v Vote) Tally(voterPool []sdk.ValAddress, votes []VoterChoice, quorum uint64) Tally {
+	if votes == nil {
+		votes = []VoterChoice{}
+	}
+	voted := make(map[string]bool, len(votes))
+	for _, choice := range votes {
+		voted[choice.Voter.String()] = true
+	}
+
+	remainingVoters := []sdk.ValAddress{}
+	for _, voter := range voterPool {
+		if !voted[voter.String()] {
+			remainingVoters = append(remainingVoters, voter)
+		}
+	}
+
+	requiredApprovals := NecessaryApprovals(uint64(len(voterPool)), quorum)
+
+	return Tally{
+		Vote:              v,
+		Votes:             votes,
+		VoterPoolSize:     uint64(len(voterPool)),
+		RequiredApprovals: requiredApprovals,
+		RemainingVoters:   remainingVoters,
+		CanPass:           v.Approvals+uint64(len(remainingVoters)) >= requiredApprovals,
+	}
+}
+
 // Vote encoding functions
 func MustMarshalVote(cdc *codec.Codec, v Vote) []byte {
 	return cdc.MustMarshalBinaryBare(&v)
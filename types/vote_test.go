@@ -3,66 +3,39 @@ package types_test
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/ltacker/poa"
 	"github.com/ltacker/poa/types"
 )
 
-func TestAddVote(t *testing.T) {
+func TestCountVote(t *testing.T) {
 	validator, _ := poa.MockValidator()
-	account1 := poa.MockValAddress()
-	account2 := poa.MockValAddress()
 	vote := types.NewVote(validator)
 
 	if vote.GetTotal() != 0 {
 		t.Errorf("Vote should contain no vote when created")
 	}
 
-	alreadyVoted := vote.AddVote(account1, true)
-	if alreadyVoted != false {
-		t.Errorf("AddVote should return false if the voter hasn't voted yet")
-	}
+	vote.CountVote(true)
 	if vote.GetTotal() != 1 {
-		t.Errorf("AddVote should increase the number of votes in the vote")
+		t.Errorf("CountVote should increase the number of votes in the vote")
 	}
 	if vote.GetApprovals() != 1 {
-		t.Errorf("AddVote with approval should increase the number of approvals in the vote")
+		t.Errorf("CountVote with approval should increase the number of approvals in the vote")
 	}
 
-	alreadyVoted = vote.AddVote(account2, false)
-	if alreadyVoted != false {
-		t.Errorf("AddVote should return false if the voter hasn't voted yet")
-	}
+	vote.CountVote(false)
 	if vote.GetTotal() != 2 {
-		t.Errorf("AddVote should increase the number of votes in the vote")
+		t.Errorf("CountVote should increase the number of votes in the vote")
 	}
 	if vote.GetApprovals() != 1 {
-		t.Errorf("AddVote with reject should not increase the number of approvals in the vote")
-	}
-
-	alreadyVoted = vote.AddVote(account1, true)
-	if alreadyVoted != true {
-		t.Errorf("AddVote should return true if the voter has already voted")
-	}
-	if vote.GetTotal() != 2 {
-		t.Errorf("AddVote should not increase the number of votes if the voter has already voted")
-	}
-
-	alreadyVoted = vote.AddVote(account2, true)
-	if alreadyVoted != true {
-		t.Errorf("AddVote should return true if the voter has already voted")
-	}
-	if vote.GetTotal() != 2 {
-		t.Errorf("AddVote should not increase the number of votes if the voter has already voted")
+		t.Errorf("CountVote with reject should not increase the number of approvals in the vote")
 	}
 }
 
 func TestCheckQuorum(t *testing.T) {
 	validator, _ := poa.MockValidator()
-	account1 := poa.MockValAddress()
-	account2 := poa.MockValAddress()
-	account3 := poa.MockValAddress()
-	account4 := poa.MockValAddress()
-	account5 := poa.MockValAddress()
 	vote1 := types.NewVote(validator)
 	vote2 := types.NewVote(validator)
 	vote3 := types.NewVote(validator)
@@ -84,47 +57,87 @@ func TestCheckQuorum(t *testing.T) {
 	if reached == true || approved == true || err != nil {
 		t.Errorf("100 percents: Quorum should not be reached with 0/2 vote, %v, %v, %v", reached, approved, err)
 	}
-	vote1.AddVote(account1, true)
+	vote1.CountVote(true)
 	reached, approved, err = vote1.CheckQuorum(2, 100)
 	if reached == true || approved == true || err != nil {
 		t.Errorf("100 percents: Quorum should not be reached with 1/2 votes, %v, %v, %v", reached, approved, err)
 	}
-	vote1.AddVote(account2, true)
+	vote1.CountVote(true)
 	reached, approved, err = vote1.CheckQuorum(2, 100)
 	if reached == false || approved == false || err != nil {
 		t.Errorf("100 percents: Quorum should be reached with 2/2 votes, %v, %v, %v", reached, approved, err)
 	}
 
 	// Quorum of 50 means more than half of the voters must approve the vote
-	vote2.AddVote(account1, true)
-	vote2.AddVote(account2, true)
+	vote2.CountVote(true)
+	vote2.CountVote(true)
 	reached, approved, err = vote2.CheckQuorum(5, 50)
 	if reached == true || approved == true || err != nil {
 		t.Errorf("50 percents: Quorum should not be reached with 2/5 votes, %v, %v, %v", reached, approved, err)
 	}
-	vote2.AddVote(account3, false)
-	vote2.AddVote(account4, false)
+	vote2.CountVote(false)
+	vote2.CountVote(false)
 	reached, approved, err = vote2.CheckQuorum(5, 50)
 	if reached == true || approved == true || err != nil {
 		t.Errorf("50 percents: Quorum should not be reached with 2/5 approvals, %v, %v, %v", reached, approved, err)
 	}
-	vote2.AddVote(account5, true)
+	vote2.CountVote(true)
 	reached, approved, err = vote2.CheckQuorum(5, 50)
 	if reached == false || approved == false || err != nil {
 		t.Errorf("50 percents: Quorum should be reached with 3/5 approvals, %v, %v, %v", reached, approved, err)
 	}
 
 	// Quorum is reached and vote rejected if the required number of approval cannot be reached
-	vote3.AddVote(account1, false)
-	vote3.AddVote(account2, false)
+	vote3.CountVote(false)
+	vote3.CountVote(false)
 	reached, approved, err = vote3.CheckQuorum(6, 66)
 	if reached == true || approved == true || err != nil {
 		t.Errorf("Vote3 quorum should not be reached with 2 votes, %v, %v, %v", reached, approved, err)
 	}
 	// With 3 rejections, the approval cannot be reached anymore
-	vote3.AddVote(account3, false)
+	vote3.CountVote(false)
 	reached, approved, err = vote3.CheckQuorum(6, 66)
 	if reached == false || approved == true || err != nil {
 		t.Errorf("Vote3 should have a reached quorum but not approved (rejected), %v, %v, %v", reached, approved, err)
 	}
 }
+
+func TestTally(t *testing.T) {
+	validator, _ := poa.MockValidator()
+	voter1 := poa.MockValAddress()
+	voter2 := poa.MockValAddress()
+	voter3 := poa.MockValAddress()
+	voterPool := []sdk.ValAddress{voter1, voter2, voter3}
+	vote := types.NewVote(validator)
+	vote.CountVote(false)
+	votes := []types.VoterChoice{{Voter: voter1, Approve: false}}
+
+	// 2 approvals are necessary with a quorum of 66% and 3 voters
+	tally := vote.Tally(voterPool, votes, 66)
+	if tally.VoterPoolSize != 3 {
+		t.Errorf("Tally should have a voter pool of 3, got %v", tally.VoterPoolSize)
+	}
+	if tally.RequiredApprovals != types.NecessaryApprovals(3, 66) || tally.RequiredApprovals != 2 {
+		t.Errorf("Tally should require 2 approvals, got %v", tally.RequiredApprovals)
+	}
+	if len(tally.RemainingVoters) != 2 || !tally.RemainingVoters[0].Equals(voter2) || !tally.RemainingVoters[1].Equals(voter3) {
+		t.Errorf("Tally should have the voters who have not voted as remaining voters, got %v", tally.RemainingVoters)
+	}
+	if len(tally.Votes) != 1 || !tally.Votes[0].Voter.Equals(voter1) {
+		t.Errorf("Tally should contain the choice of the voter, got %v", tally.Votes)
+	}
+	if !tally.CanPass {
+		t.Errorf("Tally should be able to pass with 2 remaining voters")
+	}
+
+	// The vote can't pass anymore after a second rejection
+	vote.CountVote(false)
+	votes = append(votes, types.VoterChoice{Voter: voter2, Approve: false})
+	tally = vote.Tally(voterPool, votes, 66)
+	if len(tally.RemainingVoters) != 1 {
+		t.Errorf("Tally should have 1 remaining voter, got %v", len(tally.RemainingVoters))
+	}
+	if tally.CanPass {
+		t.Errorf("Tally should not be able to pass with 2 rejections")
+	}
+}